@@ -0,0 +1,51 @@
+package regfishapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Account describes the account the API key belongs to. The API documents
+// few account fields, so the well-known ones are decoded when present and
+// the complete payload is kept in Raw for anything else, e.g. quotas.
+type Account struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Plan  string `json:"plan"`
+	// DomainCount is the number of domains of the account, counted with
+	// ListDomains.
+	DomainCount int `json:"-"`
+	// Raw is the "response" object of GET /account as sent by the API.
+	Raw json.RawMessage `json:"-"`
+}
+
+// AccountInfo returns information about the account, e.g. to show how
+// close it is to its limits. It makes two requests: one for the account
+// and one, possibly paginated, to count the domains.
+func (c *Client) AccountInfo() (Account, error) {
+	return c.AccountInfoContext(context.Background())
+}
+
+// AccountInfoContext is like AccountInfo but uses ctx for the underlying requests.
+func (c *Client) AccountInfoContext(ctx context.Context) (Account, error) {
+	raw, err := c.DoContext(ctx, "GET", "/account", nil)
+	if err != nil {
+		return Account{}, err
+	}
+	var account Account
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &account); err != nil {
+			return Account{}, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	account.Raw = raw
+
+	domains, err := c.ListDomainsContext(ctx)
+	if err != nil {
+		return Account{}, err
+	}
+	account.DomainCount = len(domains)
+	return account, nil
+}
@@ -0,0 +1,34 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountInfo(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/account":
+			w.Write([]byte(`{"success":true,"response":{"id":42,"name":"Example GmbH","email":"ops@example.com","plan":"business","domain_limit":100}}`))
+		case "/domain":
+			w.Write([]byte(`{"response":[{"name":"example.com"},{"name":"example.org"}]}`))
+		}
+	})
+
+	account, err := client.AccountInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, 42, account.ID)
+	assert.Equal(t, "Example GmbH", account.Name)
+	assert.Equal(t, "ops@example.com", account.Email)
+	assert.Equal(t, "business", account.Plan)
+	assert.Equal(t, 2, account.DomainCount)
+
+	var extra struct {
+		DomainLimit int `json:"domain_limit"`
+	}
+	assert.NoError(t, json.Unmarshal(account.Raw, &extra))
+	assert.Equal(t, 100, extra.DomainLimit)
+}
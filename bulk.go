@@ -0,0 +1,325 @@
+package regfishapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultConcurrency is the number of requests batch operations run in
+// parallel when Client.Concurrency is not set.
+const DefaultConcurrency = 4
+
+// RecordError reports the failure of one record in a batch operation.
+type RecordError struct {
+	// Index is the position of the record in the batch.
+	Index  int
+	Record Record
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record %d (%s %s): %v", e.Index, e.Record.Name, e.Record.Type, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError is returned by batch operations when some records failed. The
+// records that aren't listed succeeded.
+type BatchError struct {
+	Errors []*RecordError
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d records failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the per-record errors, so errors.Is and errors.As look
+// through them.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// CreateRecords creates records concurrently, bounded by Client.Concurrency.
+// The returned slice is aligned with records; entries that failed are left
+// zero and reported in a *BatchError.
+//
+// Like all batch operations, CreateRecordsContext stops starting requests
+// once ctx is done and cancels the ones in flight. It then returns the
+// partial result with an error matching ctx.Err().
+func (c *Client) CreateRecords(records []Record) ([]Record, error) {
+	return c.CreateRecordsContext(context.Background(), records)
+}
+
+// CreateRecordsContext is like CreateRecords but uses ctx for the underlying requests.
+func (c *Client) CreateRecordsContext(ctx context.Context, records []Record) ([]Record, error) {
+	created := make([]Record, len(records))
+	errs := make([]error, len(records))
+	_, stopErr := c.forEach(ctx, len(records), func(i int) {
+		created[i], errs[i] = c.CreateRecordContext(ctx, records[i])
+	})
+	return created, joinStopErr(stopErr, newBatchError(records, errs))
+}
+
+// DeleteRecords deletes the records with the given RRIDs concurrently,
+// bounded by Client.Concurrency. A failed delete doesn't stop the others.
+// The result maps the RRID of every failed delete to its error and is nil
+// if all of them succeeded. If ctx is done before all deletes were started,
+// the ones left out map to ctx.Err().
+func (c *Client) DeleteRecords(rrids []int) map[int]error {
+	return c.DeleteRecordsContext(context.Background(), rrids)
+}
+
+// DeleteRecordsContext is like DeleteRecords but uses ctx for the underlying requests.
+func (c *Client) DeleteRecordsContext(ctx context.Context, rrids []int) map[int]error {
+	return c.deleteRecords(ctx, rrids, false)
+}
+
+// DeleteRecordsIgnoreMissing is like DeleteRecords but treats records that
+// don't exist as deleted, which makes repeating a cleanup safe.
+func (c *Client) DeleteRecordsIgnoreMissing(rrids []int) map[int]error {
+	return c.DeleteRecordsIgnoreMissingContext(context.Background(), rrids)
+}
+
+// DeleteRecordsIgnoreMissingContext is like DeleteRecordsIgnoreMissing but uses ctx for the underlying requests.
+func (c *Client) DeleteRecordsIgnoreMissingContext(ctx context.Context, rrids []int) map[int]error {
+	return c.deleteRecords(ctx, rrids, true)
+}
+
+// UpdateRecords updates records by their ID concurrently, bounded by
+// Client.Concurrency. A failed update doesn't stop the others. The result
+// maps the ID of every failed update to its error and is nil if all of
+// them succeeded; records without an ID fail under ID 0. If ctx is done
+// before all updates were started, the ones left out map to ctx.Err().
+func (c *Client) UpdateRecords(records []Record) map[int]error {
+	return c.UpdateRecordsContext(context.Background(), records)
+}
+
+// UpdateRecordsContext is like UpdateRecords but uses ctx for the underlying requests.
+func (c *Client) UpdateRecordsContext(ctx context.Context, records []Record) map[int]error {
+	errs := make([]error, len(records))
+	started, stopErr := c.forEach(ctx, len(records), func(i int) {
+		if records[i].ID == 0 {
+			errs[i] = &ValidationError{Field: "id", Message: "must be set to update a record"}
+			return
+		}
+		_, errs[i] = c.UpdateRecordByIdContext(ctx, records[i].ID, records[i])
+	})
+	ids := make([]int, len(records))
+	for i, r := range records {
+		ids[i] = r.ID
+	}
+	return failedByID(ids, errs, started, stopErr)
+}
+
+// ReplaceData updates every record of the account whose data equals
+// oldData, as matched by FindRecordsByData, to newData and returns how many
+// were changed, e.g. to repoint all records from a retired server to its
+// successor. Updates run concurrently, bounded by Client.Concurrency. A
+// failed update doesn't stop the others; failures, including domains that
+// couldn't be searched, are joined into the returned error.
+func (c *Client) ReplaceData(oldData, newData string) (int, error) {
+	return c.ReplaceDataContext(context.Background(), oldData, newData)
+}
+
+// ReplaceDataContext is like ReplaceData but uses ctx for the underlying requests.
+func (c *Client) ReplaceDataContext(ctx context.Context, oldData, newData string) (int, error) {
+	records, findErr := c.FindRecordsByDataContext(ctx, oldData)
+	errs := make([]error, len(records))
+	started, stopErr := c.forEach(ctx, len(records), func(i int) {
+		r := records[i]
+		r.Data = newData
+		if _, err := c.UpdateRecordByIdContext(ctx, r.ID, r); err != nil {
+			errs[i] = fmt.Errorf("update %s %s (id %d): %w", r.Name, r.Type, r.ID, err)
+		}
+	})
+
+	changed := 0
+	for _, err := range errs[:started] {
+		if err == nil {
+			changed++
+		}
+	}
+	return changed, joinStopErr(stopErr, errors.Join(append([]error{findErr}, errs[:started]...)...))
+}
+
+func (c *Client) deleteRecords(ctx context.Context, rrids []int, ignoreMissing bool) map[int]error {
+	errs := make([]error, len(rrids))
+	started, stopErr := c.forEach(ctx, len(rrids), func(i int) {
+		err := c.deleteRecord(ctx, rrids[i])
+		if ignoreMissing && errors.Is(err, ErrNotFound) {
+			err = nil
+		}
+		errs[i] = err
+	})
+	return failedByID(rrids, errs, started, stopErr)
+}
+
+// failedByID maps the IDs whose entry in errs, aligned with ids, is set to
+// that error. IDs from started on weren't started and map to stopErr. It
+// returns nil if nothing failed.
+func failedByID(ids []int, errs []error, started int, stopErr error) map[int]error {
+	for i := started; i < len(errs); i++ {
+		errs[i] = stopErr
+	}
+
+	var failed map[int]error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if failed == nil {
+			failed = make(map[int]error)
+		}
+		failed[ids[i]] = err
+	}
+	return failed
+}
+
+// newBatchError collects the non-nil entries of errs, which is aligned with
+// records, into a *BatchError. It returns nil if all entries are nil.
+func newBatchError(records []Record, errs []error) error {
+	var failed []*RecordError
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, &RecordError{Index: i, Record: records[i], Err: err})
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &BatchError{Errors: failed}
+}
+
+// forEach calls fn for every index in [0, n), running up to
+// Client.Concurrency calls at once, and waits for all of them to return.
+// Indices are started in order. Once ctx is done, no further calls are
+// started; forEach then returns the number of indices it started and
+// ctx.Err().
+func (c *Client) forEach(ctx context.Context, n int, fn func(i int)) (started int, err error) {
+	workers := c.Concurrency
+	if workers <= 0 {
+		workers = DefaultConcurrency
+	}
+	if workers > n {
+		workers = n
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for started < n && err == nil {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case next <- started:
+			started++
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	close(next)
+	wg.Wait()
+	return started, err
+}
+
+// joinStopErr combines the error forEach returned when it stopped early
+// with the errors of the calls that were made.
+func joinStopErr(stopErr, err error) error {
+	if stopErr == nil {
+		return err
+	}
+	return errors.Join(stopErr, err)
+}
+
+// DeleteRecordsByName deletes every record of domain named name and returns
+// how many were removed. A failed delete doesn't stop the remaining ones;
+// all failures are joined into the returned error. An empty name, "@" and
+// domain refer to the apex.
+func (c *Client) DeleteRecordsByName(domain, name string) (int, error) {
+	return c.DeleteRecordsByNameContext(context.Background(), domain, name)
+}
+
+// DeleteRecordsByNameContext is like DeleteRecordsByName but uses ctx for the underlying requests.
+func (c *Client) DeleteRecordsByNameContext(ctx context.Context, domain, name string) (int, error) {
+	records, err := c.FindRecordsContext(ctx, domain, apexName(name, domain), "")
+	if err != nil {
+		return 0, err
+	}
+	return c.deleteEach(ctx, records)
+}
+
+// DeleteRecordsByTag deletes every record of domain whose Tag is tag and
+// returns how many were removed. A failed delete doesn't stop the
+// remaining ones; all failures are joined into the returned error.
+func (c *Client) DeleteRecordsByTag(domain, tag string) (int, error) {
+	return c.DeleteRecordsByTagContext(context.Background(), domain, tag)
+}
+
+// DeleteRecordsByTagContext is like DeleteRecordsByTag but uses ctx for the underlying requests.
+func (c *Client) DeleteRecordsByTagContext(ctx context.Context, domain, tag string) (int, error) {
+	records, err := c.GetRecordsByTagContext(ctx, domain, tag)
+	if err != nil {
+		return 0, err
+	}
+	return c.deleteEach(ctx, records)
+}
+
+// DeleteDuplicates deletes the records FindDuplicates returns for domain,
+// keeping one record of every set of duplicates, and returns how many were
+// removed. A failed delete doesn't stop the remaining ones; all failures
+// are joined into the returned error.
+func (c *Client) DeleteDuplicates(domain string) (int, error) {
+	return c.DeleteDuplicatesContext(context.Background(), domain)
+}
+
+// DeleteDuplicatesContext is like DeleteDuplicates but uses ctx for the underlying requests.
+func (c *Client) DeleteDuplicatesContext(ctx context.Context, domain string) (int, error) {
+	records, err := c.FindDuplicatesContext(ctx, domain)
+	if err != nil {
+		return 0, err
+	}
+	return c.deleteEach(ctx, records)
+}
+
+// deleteEach deletes records one after the other and returns how many
+// were removed, joining the errors of the failed ones.
+func (c *Client) deleteEach(ctx context.Context, records []Record) (int, error) {
+	deleted := 0
+	var errs []error
+	for _, r := range records {
+		if err := ctx.Err(); err != nil {
+			return deleted, errors.Join(append([]error{err}, errs...)...)
+		}
+		if err := c.deleteRecord(ctx, r.ID); err != nil {
+			errs = append(errs, fmt.Errorf("delete %s %s (id %d): %w", r.Name, r.Type, r.ID, err))
+			continue
+		}
+		deleted++
+	}
+	return deleted, errors.Join(errs...)
+}
@@ -0,0 +1,257 @@
+package regfishapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteRecordsByName(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(testZone))
+			return
+		}
+		if r.URL.Path == "/dns/rr/2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		mu.Lock()
+		deleted = append(deleted, r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(`{"success":true}`))
+	})
+
+	n, err := client.DeleteRecordsByName("example.com", "www.example.com")
+	assert.Equal(t, 1, n)
+	assert.ErrorContains(t, err, "id 2")
+	sort.Strings(deleted)
+	assert.Equal(t, []string{"/dns/rr/3"}, deleted)
+}
+
+func TestCreateRecords(t *testing.T) {
+	var inflight, peak int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		var rec Record
+		json.NewDecoder(r.Body).Decode(&rec)
+		if rec.Data == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"invalid data"}`))
+			return
+		}
+		rec.ID = 100
+		json.NewEncoder(w).Encode(map[string]Record{"response": rec})
+	}, WithConcurrency(3))
+
+	records := make([]Record, 10)
+	for i := range records {
+		records[i] = Record{Name: fmt.Sprintf("h%d.example.com.", i), Type: "A", Data: "192.0.2.1"}
+	}
+	records[4].Data = "bad"
+	records[7].Data = "bad"
+
+	created, err := client.CreateRecords(records)
+	assert.Len(t, created, 10)
+	assert.Equal(t, "h0.example.com.", created[0].Name)
+	assert.Equal(t, 100, created[0].ID)
+	assert.Zero(t, created[4].ID)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+
+	var batchErr *BatchError
+	if assert.True(t, errors.As(err, &batchErr)) {
+		if assert.Len(t, batchErr.Errors, 2) {
+			assert.Equal(t, 4, batchErr.Errors[0].Index)
+			assert.Equal(t, 7, batchErr.Errors[1].Index)
+		}
+	}
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+}
+
+func TestDeleteRecords(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dns/rr/2":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case "/dns/rr/3":
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		deleted = append(deleted, r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(`{"success":true}`))
+	}, WithConcurrency(2))
+
+	failed := client.DeleteRecords([]int{1, 2, 3, 4})
+	sort.Strings(deleted)
+	assert.Equal(t, []string{"/dns/rr/1", "/dns/rr/4"}, deleted)
+	assert.Len(t, failed, 2)
+	assert.ErrorIs(t, failed[2], ErrRecordNotFound)
+	assert.Error(t, failed[3])
+
+	failed = client.DeleteRecordsIgnoreMissing([]int{1, 2, 3})
+	assert.Len(t, failed, 1)
+	assert.Contains(t, failed, 3)
+
+	assert.Nil(t, client.DeleteRecordsIgnoreMissing([]int{1, 2}))
+}
+
+func TestUpdateRecords(t *testing.T) {
+	zone := newFakeZone(
+		Record{ID: 1, Name: "a.example.com.", Type: "A", Data: "192.0.2.1"},
+		Record{ID: 2, Name: "b.example.com.", Type: "A", Data: "192.0.2.2"},
+	)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/dns/rr/3" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		zone.ServeHTTP(w, r)
+	}, WithConcurrency(2))
+
+	failed := client.UpdateRecords([]Record{
+		{ID: 1, Name: "a.example.com.", Type: "A", Data: "192.0.2.11"},
+		{ID: 2, Name: "b.example.com.", Type: "A", Data: "192.0.2.12"},
+		{ID: 3, Name: "c.example.com.", Type: "A", Data: "192.0.2.13"},
+		{Name: "d.example.com.", Type: "A", Data: "192.0.2.14"},
+	})
+	assert.Len(t, failed, 2)
+	assert.ErrorIs(t, failed[3], ErrRecordNotFound)
+	var verr *ValidationError
+	assert.True(t, errors.As(failed[0], &verr))
+	assert.Equal(t, "192.0.2.11", zone.records[1].Data)
+	assert.Equal(t, "192.0.2.12", zone.records[2].Data)
+
+	assert.Nil(t, client.UpdateRecords([]Record{{ID: 1, Name: "a.example.com.", Type: "A", Data: "192.0.2.1"}}))
+}
+
+func TestBatchStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"response":{"id":1}}`))
+	}, WithConcurrency(1), WithOnResponse(func(*http.Response, time.Duration) { cancel() }))
+
+	records := make([]Record, 5)
+	for i := range records {
+		records[i] = NewARecord(fmt.Sprintf("r%d.example.com.", i), "192.0.2.1", 0)
+	}
+	created, err := client.CreateRecordsContext(ctx, records)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, created, 5)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	assert.Equal(t, 1, created[0].ID)
+
+	failed := client.DeleteRecordsContext(ctx, []int{1, 2, 3})
+	assert.Len(t, failed, 3)
+	for _, err := range failed {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
+
+func TestDeleteRecordsByTag(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(annotatedZone))
+			return
+		}
+		mu.Lock()
+		deleted = append(deleted, r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(`{"success":true}`))
+	})
+
+	n, err := client.DeleteRecordsByTag("example.com", "ci")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"/dns/rr/1", "/dns/rr/3"}, deleted)
+}
+
+func TestReplaceData(t *testing.T) {
+	zone := newFakeZone(
+		Record{ID: 1, Name: "a.example.com.", Type: "A", Data: "192.0.2.1"},
+		Record{ID: 2, Name: "b.example.com.", Type: "A", Data: "192.0.2.2"},
+		Record{ID: 3, Name: "c.example.com.", Type: "A", Data: "192.0.2.1"},
+	)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/domain":
+			w.Write([]byte(`{"response":[{"name":"example.com"}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/dns/rr/3":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			zone.ServeHTTP(w, r)
+		}
+	})
+
+	changed, err := client.ReplaceData("192.0.2.1", "192.0.2.10")
+	assert.Equal(t, 1, changed)
+	assert.ErrorContains(t, err, "update c.example.com. A (id 3)")
+	assert.Equal(t, "192.0.2.10", zone.records[1].Data)
+	assert.Equal(t, "192.0.2.2", zone.records[2].Data)
+	assert.Equal(t, "192.0.2.1", zone.records[3].Data)
+
+	changed, err = client.ReplaceData("198.51.100.1", "192.0.2.10")
+	assert.NoError(t, err)
+	assert.Zero(t, changed)
+}
+
+func TestDeleteDuplicates(t *testing.T) {
+	zone := newFakeZone(
+		Record{ID: 1, Name: "www.example.com.", Type: "A", Data: "192.0.2.1"},
+		Record{ID: 2, Name: "WWW.example.com", Type: "A", Data: "192.0.2.1", TTL: IntPtr(60)},
+		Record{ID: 3, Name: "www.example.com.", Type: "A", Data: "192.0.2.2"},
+		Record{ID: 4, Name: "www.example.com.", Type: "AAAA", Data: "2001:db8::1"},
+		Record{ID: 5, Name: "www.example.com.", Type: "AAAA", Data: "2001:0db8::0001"},
+		Record{ID: 6, Name: "www.example.com.", Type: "A", Data: "192.0.2.1"},
+	)
+	client := newTestClient(t, zone.ServeHTTP)
+
+	dups, err := client.FindDuplicates("example.com")
+	assert.NoError(t, err)
+	ids := make([]int, len(dups))
+	for i, r := range dups {
+		ids[i] = r.ID
+	}
+	assert.Equal(t, []int{2, 5, 6}, ids)
+
+	n, err := client.DeleteDuplicates("example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Len(t, zone.records, 3)
+	assert.Contains(t, zone.records, 1)
+	assert.Contains(t, zone.records, 3)
+	assert.Contains(t, zone.records, 4)
+
+	dups, err = client.FindDuplicates("example.com")
+	assert.NoError(t, err)
+	assert.Empty(t, dups)
+}
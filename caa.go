@@ -0,0 +1,91 @@
+package regfishapi
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// CAA property tags (RFC 8659).
+const (
+	CAATagIssue     = "issue"
+	CAATagIssueWild = "issuewild"
+	CAATagIODEF     = "iodef"
+)
+
+// CAAFlagCritical is the issuer-critical flag of a CAA record.
+const CAAFlagCritical = 128
+
+// validateCAA checks the flags, tag and value of a CAA record.
+func validateCAA(r Record) error {
+	if r.Flags == nil {
+		return &ValidationError{Field: "flags", Message: "is required for CAA records"}
+	}
+	if *r.Flags != 0 && *r.Flags != CAAFlagCritical {
+		return &ValidationError{Field: "flags", Message: fmt.Sprintf("must be 0 or %d, got %d", CAAFlagCritical, *r.Flags)}
+	}
+	if r.Tag == nil {
+		return &ValidationError{Field: "tag", Message: "is required for CAA records"}
+	}
+
+	value := strings.Trim(strings.TrimSpace(r.Data), `"`)
+	switch strings.ToLower(*r.Tag) {
+	case CAATagIssue, CAATagIssueWild:
+		if err := validateCAAIssuer(value); err != nil {
+			return &ValidationError{Field: "data", Message: err.Error()}
+		}
+	case CAATagIODEF:
+		if err := validateCAAIODEF(value); err != nil {
+			return &ValidationError{Field: "data", Message: err.Error()}
+		}
+	default:
+		return &ValidationError{Field: "tag", Message: fmt.Sprintf("must be %q, %q or %q, got %q", CAATagIssue, CAATagIssueWild, CAATagIODEF, *r.Tag)}
+	}
+	return nil
+}
+
+// validateCAAIssuer checks an issue/issuewild value: an optional CA domain
+// name followed by optional "; key=value" parameters. A lone ";" forbids
+// issuance by any CA.
+func validateCAAIssuer(value string) error {
+	domain, params, _ := strings.Cut(value, ";")
+	domain = strings.TrimSpace(domain)
+	if domain != "" {
+		if err := validateName(domain); err != nil || strings.ContainsAny(domain, "/:@") {
+			return fmt.Errorf("%q is not a CA domain name", domain)
+		}
+	}
+	for _, p := range strings.Split(params, ";") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if k, v, ok := strings.Cut(p, "="); !ok || strings.TrimSpace(k) == "" || strings.TrimSpace(v) == "" {
+			return fmt.Errorf("parameter %q is not of the form key=value", p)
+		}
+	}
+	return nil
+}
+
+// validateCAAIODEF checks an iodef value, which must be a mailto: or
+// http(s) URL.
+func validateCAAIODEF(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a URL", value)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "mailto":
+		if _, err := mail.ParseAddress(u.Opaque); err != nil {
+			return fmt.Errorf("%q is not a valid mailto URL", value)
+		}
+	case "http", "https":
+		if u.Host == "" {
+			return fmt.Errorf("%q has no host", value)
+		}
+	default:
+		return fmt.Errorf("%q must be a mailto:, http:// or https:// URL", value)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package regfishapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCAA(t *testing.T) {
+	tests := []struct {
+		name   string
+		record Record
+		field  string
+	}{
+		{"issue", NewCAARecord("example.com.", 0, "issue", "letsencrypt.org", 0), ""},
+		{"issue with params", NewCAARecord("example.com.", 0, "issue", "letsencrypt.org; validationmethods=dns-01", 0), ""},
+		{"deny all", NewCAARecord("example.com.", 0, "issuewild", ";", 0), ""},
+		{"critical iodef mailto", NewCAARecord("example.com.", 128, "iodef", "mailto:security@example.com", 0), ""},
+		{"iodef https", NewCAARecord("example.com.", 0, "iodef", "https://example.com/caa", 0), ""},
+		{"bad flags", NewCAARecord("example.com.", 1, "issue", "letsencrypt.org", 0), "flags"},
+		{"bad tag", NewCAARecord("example.com.", 0, "issues", "letsencrypt.org", 0), "tag"},
+		{"issue url", NewCAARecord("example.com.", 0, "issue", "https://letsencrypt.org", 0), "data"},
+		{"bad param", NewCAARecord("example.com.", 0, "issue", "letsencrypt.org; nokey", 0), "data"},
+		{"iodef plain email", NewCAARecord("example.com.", 0, "iodef", "security@example.com", 0), "data"},
+		{"missing tag", Record{Name: "example.com.", Type: "CAA", Data: "letsencrypt.org", Flags: new(int)}, "tag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.record.Validate()
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var verr *ValidationError
+			if assert.True(t, errors.As(err, &verr), "got %v", err) {
+				assert.Equal(t, tt.field, verr.Field)
+			}
+		})
+	}
+}
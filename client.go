@@ -2,31 +2,257 @@ package regfishapi
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultMaxResponseBytes is the response size limit used when
+// Client.MaxResponseBytes is zero.
+const DefaultMaxResponseBytes = 8 << 20
+
+// DefaultBaseURL is the endpoint of the production Regfish API.
+const DefaultBaseURL = "https://api.regfish.de"
+
+// DefaultRequestTimeout is the RequestTimeout set by NewClient.
+const DefaultRequestTimeout = 30 * time.Second
+
+// DefaultMaxRetryAfter is the MaxRetryAfter set by NewClient.
+const DefaultMaxRetryAfter = 2 * time.Minute
+
+// Connection pool settings of the transport NewClient creates unless
+// WithHTTPClient is given. The per-host limit matches DefaultConcurrency,
+// so batch operations reuse their connections instead of reconnecting.
+const (
+	DefaultMaxIdleConns        = 16
+	DefaultMaxIdleConnsPerHost = DefaultConcurrency
+	DefaultIdleConnTimeout     = 90 * time.Second
 )
 
 // Client struct holds the API client configuration
 // including the base URL and the API key for authentication.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its exported
+// fields configure it and are read on every request without locking, so
+// set them, preferably through the options of NewClient, before the Client
+// is shared and don't modify them afterwards. State that changes as
+// requests complete, such as RateLimit, is synchronized internally.
 type Client struct {
+	// BaseURL is the API endpoint, see WithBaseURL. It must use https
+	// unless it points to a loopback host; requests fail otherwise, also
+	// if the field is set directly.
 	BaseURL string
 	APIKey  string
 	Client  *http.Client
+
+	// UserAgent is sent as the User-Agent header. NewClient sets it to
+	// DefaultUserAgent; an empty value falls back to Go's default.
+	UserAgent string
+	// RequestTimeout bounds each HTTP round trip, so that a stalled
+	// connection can't hang a call indefinitely. NewClient sets it to
+	// DefaultRequestTimeout; zero means no timeout beyond the one carried
+	// by the caller's context.
+	RequestTimeout time.Duration
+
+	// MaxRetries is the number of times a request that failed with a
+	// transient error (a network error or a status in RetryableStatusCodes)
+	// is retried. Zero disables retries. Only idempotent methods (GET,
+	// DELETE, PATCH) are retried unless RetryNonIdempotent is set, except
+	// after a 429, which the server sends without processing the request.
+	MaxRetries int
+	// RetryBackoff returns how long to wait before the given retry attempt,
+	// starting at 1. A Retry-After header sent by the server takes
+	// precedence. If nil, DefaultRetryBackoff is used.
+	RetryBackoff func(attempt int) time.Duration
+	// RetryableStatusCodes are the response statuses that are retried. If
+	// nil, DefaultRetryableStatusCodes is used; an empty slice retries
+	// network errors only.
+	RetryableStatusCodes []int
+	// MaxRetryAfter caps the wait requested by a Retry-After header.
+	// NewClient sets it to DefaultMaxRetryAfter; zero means no cap.
+	MaxRetryAfter time.Duration
+	// RetryNonIdempotent enables retries for POST requests, which may
+	// create duplicate records if the first attempt reached the server.
+	// Retried POST requests carry a generated IdempotencyKeyHeader unless
+	// the caller set one.
+	RetryNonIdempotent bool
+
+	// ValidateRecords makes CreateRecord and UpdateRecord run
+	// Record.Validate before sending, failing fast on malformed records.
+	ValidateRecords bool
+	// DecodeIDN makes the Client convert punycode record names returned by
+	// the API back to Unicode. Outgoing names are always sent as punycode.
+	DecodeIDN bool
+	// StrictDecoding makes decoding a response fail if it contains fields
+	// the Client doesn't model, e.g. to notice API changes in tests.
+	// Account.Raw and the payload returned by Do are exempt.
+	StrictDecoding bool
+	// QualifyNames makes the Client add the trailing dot to record names
+	// that lack it before sending them, so "www.example.com" becomes
+	// "www.example.com.". The API expects fully qualified names; without
+	// QualifyNames, names are sent as given.
+	QualifyNames bool
+	// DryRun makes the Client skip POST, PATCH, PUT and DELETE requests.
+	// Instead of calling the API, these requests fail with ErrDryRun and
+	// methods returning a Record return the record that would have been
+	// sent. GET requests are still made, so lookups behave normally.
+	DryRun bool
+	// MaxResponseBytes caps the size of a response body the Client reads.
+	// Larger responses fail with ErrResponseTooLarge. Zero means
+	// DefaultMaxResponseBytes; a negative value disables the limit.
+	MaxResponseBytes int64
+	// Logger, if set, receives a trace of every request and response. See
+	// WithLogger.
+	Logger Logger
+	// OnRequest, if set, is called with every HTTP request right before it
+	// is sent, including retries. The request carries the API key, so
+	// don't log its headers verbatim.
+	OnRequest func(req *http.Request)
+	// OnResponse, if set, is called after every round trip with the
+	// response, whose body has already been consumed unless it is
+	// streamed by RequestRaw, and the time the round trip took. resp is
+	// nil if the request failed without a response, e.g. because of a
+	// network error.
+	OnResponse func(resp *http.Response, elapsed time.Duration)
+	// Header holds extra headers sent with every request, such as a
+	// correlation ID required by a proxy. See WithHeader and
+	// ContextWithHeader for per-call headers.
+	Header http.Header
+	// Concurrency bounds the number of requests batch operations such as
+	// CreateRecords run in parallel. Zero means DefaultConcurrency.
+	Concurrency int
+	// VerifyTimeout, if set, makes UpdateRecord and UpdateRecordById, and
+	// the methods built on them, wait after a successful update until the
+	// authoritative name servers serve the new data. If they don't within
+	// VerifyTimeout, the updated record is returned with an error matching
+	// ErrNotPropagated. Only the types supported by WaitForRecord are
+	// verified. See WithVerifyAfterApply.
+	VerifyTimeout time.Duration
+	// VerifyNameservers are the name servers queried by VerifyTimeout, as
+	// "host:port" or just the host for port 53. If empty, the NS records
+	// of the zone are looked up in public DNS.
+	VerifyNameservers []string
+
+	limiter *rate.Limiter
+	// lookup replaces lookupRecord in verifyApplied, for tests.
+	lookup func(ctx context.Context, resolver, name, recordType string) ([]string, error)
+	// configErr records an invalid option passed to NewClient. It is
+	// returned by every request, since options can't fail.
+	configErr error
+	// transportOpts holds changes to the default transport requested by
+	// options such as WithTLSConfig. NewClient applies them if the caller
+	// didn't supply an http.Client.
+	transportOpts []func(*http.Transport)
+	// middleware holds the RoundTripper wrappers added by WithMiddleware.
+	middleware []func(http.RoundTripper) http.RoundTripper
+
+	mu           sync.Mutex
+	rateLimit    RateLimit
+	lastResponse ResponseMeta
 }
 
 // NewClient creates a new instance of the Regfish API client.
-func NewClient(apiKey string) *Client {
-	return &Client{
-		BaseURL: "https://api.regfish.de",
-		APIKey:  apiKey,
-		Client:  &http.Client{},
+// Options are applied in order on top of the defaults.
+func NewClient(apiKey string, opts ...Option) *Client {
+	hc := &http.Client{CheckRedirect: checkRedirect}
+	c := &Client{
+		BaseURL:        DefaultBaseURL,
+		APIKey:         apiKey,
+		Client:         hc,
+		UserAgent:      DefaultUserAgent,
+		RequestTimeout: DefaultRequestTimeout,
+		MaxRetryAfter:  DefaultMaxRetryAfter,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Client == hc {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.MaxIdleConns = DefaultMaxIdleConns
+		t.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+		t.IdleConnTimeout = DefaultIdleConnTimeout
+		for _, f := range c.transportOpts {
+			f(t)
+		}
+		hc.Transport = t
+	}
+	if len(c.middleware) > 0 {
+		// Wrap a copy, so that an http.Client passed to WithHTTPClient
+		// isn't changed for its other users.
+		wrapped := *c.Client
+		rt := wrapped.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		for _, m := range c.middleware {
+			rt = m(rt)
+		}
+		wrapped.Transport = rt
+		c.Client = &wrapped
+	}
+	return c
+}
+
+// checkRedirect is the redirect policy of the http.Client created by
+// NewClient. It refuses redirects that would send the API key, which
+// net/http forwards to the new location, in cleartext.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if req.URL.Scheme != "https" && !(req.URL.Scheme == "http" && isLoopback(req.URL.Hostname())) {
+		return fmt.Errorf("refusing redirect to %s: scheme must be https", req.URL.Redacted())
+	}
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
 	}
+	return nil
+}
+
+// CloseIdleConnections closes the idle connections kept for reuse, e.g.
+// before a short-lived program exits. Connections in use aren't affected.
+func (c *Client) CloseIdleConnections() {
+	c.Client.CloseIdleConnections()
 }
 
 // Request helper for making HTTP requests.
 func (c *Client) Request(method, endpoint string, body interface{}, headers map[string]string) ([]byte, error) {
+	return c.RequestContext(context.Background(), method, endpoint, body, headers)
+}
+
+// RequestContext is like Request but binds the HTTP request to ctx, so that
+// cancelling ctx or exceeding its deadline aborts the call in flight.
+func (c *Client) RequestContext(ctx context.Context, method, endpoint string, body interface{}, headers map[string]string) ([]byte, error) {
+	_, respBody, err := c.request(ctx, method, endpoint, body, headers)
+	return respBody, err
+}
+
+// request is like RequestContext but also returns the headers of the
+// successful response.
+func (c *Client) request(ctx context.Context, method, endpoint string, body interface{}, headers map[string]string) (http.Header, []byte, error) {
+	resp, respBody, err := c.exchange(ctx, method, endpoint, body, headers, false)
+	if err != nil {
+		return nil, respBody, err
+	}
+	return resp.Header, respBody, nil
+}
+
+// exchange marshals body and sends the request to endpoint. With stream
+// set, the body of a successful response is left unread for the caller to
+// close; otherwise it is read and returned.
+func (c *Client) exchange(ctx context.Context, method, endpoint string, body interface{}, headers map[string]string, stream bool) (*http.Response, []byte, error) {
+	if c.configErr != nil {
+		return nil, nil, c.configErr
+	}
+	if err := validateBaseURL(c.BaseURL); err != nil {
+		return nil, nil, err
+	}
 	url := fmt.Sprintf("%s%s", c.BaseURL, endpoint)
 
 	// Marshal body if provided
@@ -35,154 +261,520 @@ func (c *Client) Request(method, endpoint string, body interface{}, headers map[
 	if body != nil {
 		reqBody, err = json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal body: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal body: %w", err)
+		}
+	}
+
+	if c.DryRun && isMutation(method) {
+		return nil, dryRunResponse(reqBody), ErrDryRun
+	}
+
+	resp, respBody, err := c.send(ctx, method, url, reqBody, headers, stream)
+	if err != nil {
+		return nil, nil, c.redactError(fmt.Errorf("%s %s: %w", method, endpoint, err))
+	}
+	return resp, respBody, nil
+}
+
+// send performs the request, retrying transient failures as configured.
+func (c *Client) send(ctx context.Context, method, url string, reqBody []byte, headers map[string]string, stream bool) (*http.Response, []byte, error) {
+	headers = c.idempotencyHeaders(ctx, method, headers)
+	for attempt := 1; ; attempt++ {
+		resp, respBody, err := c.do(ctx, method, url, reqBody, headers, stream)
+		if err != nil {
+			if attempt > c.MaxRetries || !c.canRetry(method) || ctx.Err() != nil {
+				return nil, nil, err
+			}
+			if err := sleepContext(ctx, c.backoff(attempt, nil)); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode >= 400 {
+			retryable := c.isRetryableStatus(resp.StatusCode) &&
+				(c.canRetry(method) || resp.StatusCode == http.StatusTooManyRequests)
+			if attempt > c.MaxRetries || !retryable {
+				apiErr := newAPIError(resp.StatusCode, c.redactBytes(respBody))
+				apiErr.RequestID = resp.Header.Get("X-Request-Id")
+				return nil, nil, apiErr
+			}
+			if err := sleepContext(ctx, c.backoff(attempt, resp)); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		return resp, respBody, nil
+	}
+}
+
+// do performs a single HTTP round trip and reads the full response body.
+// With stream set, the body of a successful response is instead left open
+// and wrapped in a streamBody, which the caller must close.
+func (c *Client) do(ctx context.Context, method, url string, reqBody []byte, headers map[string]string, stream bool) (*http.Response, []byte, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, nil, fmt.Errorf("rate limiter: %w", err)
 		}
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(reqBody))
+	// The timeout must outlive do while a streamed body is being read, so
+	// it is released either here or when the streamBody is closed.
+	streaming := false
+	cancel := context.CancelFunc(func() {})
+	if c.RequestTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.RequestTimeout)
+	}
+	defer func() {
+		if !streaming {
+			cancel()
+		}
+	}()
+
+	// The body is marshalled once by request and every attempt reads it
+	// from a fresh reader. A *bytes.Reader also makes NewRequest set
+	// GetBody, so net/http can resend it on redirects and HTTP/2 retries.
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(reqBody))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("x-api-key", c.APIKey)
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	for k, vs := range c.Header {
+		req.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), vs...)
+	}
+	for k, vs := range headersFromContext(ctx) {
+		req.Header[k] = append([]string(nil), vs...)
+	}
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
 
+	c.logRequest(req, reqBody)
+	c.onRequest(ctx, req)
+	start := time.Now()
 	resp, err := c.Client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		c.onResponse(ctx, nil, time.Since(start))
+		if c.Logger != nil {
+			c.logf("<-- %s %s failed: %v", req.Method, req.URL, err)
+		}
+		return nil, nil, fmt.Errorf("failed to make request: %w", err)
 	}
-	defer resp.Body.Close()
+	defer func() {
+		if !streaming {
+			resp.Body.Close()
+		}
+	}()
+
+	c.observeResponse(resp)
 
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("request failed with status code %d", resp.StatusCode)
+	var body io.Reader = resp.Body
+	compressed := strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip")
+	if compressed {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			c.onResponse(ctx, resp, time.Since(start))
+			return nil, nil, fmt.Errorf("failed to decompress response body: %w", err)
+		}
+		defer func() {
+			if !streaming {
+				gz.Close()
+			}
+		}()
+		body = gz
 	}
 
-	respBody, err := io.ReadAll(resp.Body)
+	if stream && resp.StatusCode < 400 {
+		streaming = true
+		if compressed {
+			// Present the body as net/http does when it decompresses
+			// transparently.
+			resp.Header.Del("Content-Encoding")
+			resp.Header.Del("Content-Length")
+			resp.ContentLength = -1
+			resp.Uncompressed = true
+		}
+		resp.Body = &streamBody{Reader: body, body: resp.Body, cancel: cancel}
+		elapsed := time.Since(start)
+		c.onResponse(ctx, resp, elapsed)
+		c.logResponse(req, resp, nil, elapsed)
+		return resp, nil, nil
+	}
+
+	respBody, err := c.readBody(body)
+	elapsed := time.Since(start)
+	c.onResponse(ctx, resp, elapsed)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, nil, err
 	}
+	c.logResponse(req, resp, respBody, elapsed)
+
+	return resp, respBody, nil
+}
+
+// streamBody is the body of a streamed response. It reads the decompressed
+// stream and releases the request's timeout when closed.
+type streamBody struct {
+	io.Reader
+	body   io.Closer
+	cancel context.CancelFunc
+}
 
+// Close closes the underlying response body.
+func (b *streamBody) Close() error {
+	err := b.body.Close()
+	b.cancel()
+	return err
+}
+
+// readBody reads r up to the configured MaxResponseBytes. For compressed
+// responses r is the decompressed stream, so the limit also guards against
+// decompression bombs.
+func (c *Client) readBody(r io.Reader) ([]byte, error) {
+	limit := c.MaxResponseBytes
+	if limit == 0 {
+		limit = DefaultMaxResponseBytes
+	}
+	if limit < 0 {
+		respBody, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return respBody, nil
+	}
+
+	respBody, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(respBody)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
+	}
 	return respBody, nil
 }
 
 // Record represents a DNS record with common fields.
+//
+// TTL is a pointer so that an unset TTL, which is left out of requests and
+// lets the API apply its default, is distinct from an explicit TTL of 0.
+// Use IntPtr to set it. The constructors taking a ttl argument leave TTL
+// unset for a ttl of 0.
+//
+// Priority, Flags and the other pointer fields are sent whenever they are
+// set, including to 0, so a primary MX with preference 0 keeps it. The MX
+// and SRV constructors always set Priority.
+//
+// Type is a plain string for convenience; see RecordType for the known
+// types. It is sent in upper case whatever case it is given in.
+//
+// Name is the name of the record as sent to the API. The zone apex is
+// named by the fully qualified domain, e.g. "example.com."; methods that
+// take a domain, such as UpsertRecord and CreateRecordInZone, also accept
+// "@", an empty name or the bare domain for it and send the canonical
+// form.
 type Record struct {
-	ID         int     `json:"id"`
-	Name       string  `json:"name"`
-	Type       string  `json:"type"`
-	Data       string  `json:"data"`
-	TTL        int     `json:"ttl,omitempty"`
-	Priority   *int    `json:"priority,omitempty"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Data     string `json:"data"`
+	TTL      *int   `json:"ttl,omitempty"`
+	Priority *int   `json:"priority,omitempty"`
+	// Annotation is the free-text comment stored with the record, e.g. to
+	// note who owns it; the API has no separate comment field. It is nil
+	// if the API returned none; a nil Annotation is left out of requests,
+	// so an update keeps the existing comment. Use SetRecordComment to
+	// remove it. See GetRecordsByAnnotation.
 	Annotation *string `json:"annotation,omitempty"`
-	Tag        *string `json:"tag,omitempty"`
-	Flags      *int    `json:"flags,omitempty"`
+	// Tag is the property tag of CAA records; other records may carry a
+	// tag to group them, see GetRecordsByTag. Like Annotation, a nil Tag
+	// is left out of requests.
+	Tag   *string `json:"tag,omitempty"`
+	Flags *int    `json:"flags,omitempty"`
+
+	// Zone is the domain the record was listed under. It is filled in by
+	// GetRecordsByDomain and the methods built on it, so that records of
+	// several domains can be told apart, and is never sent to the API.
+	Zone string `json:"-"`
+	// ETag is the entity tag the API sent with the record, if any. Pass it
+	// to UpdateRecordByIdIfMatch to update the record only if it hasn't
+	// changed since. It is never sent to the API.
+	ETag string `json:"-"`
 }
 
-// GetRecord retrieves details about a specific DNS record by RRID.
+// GetRecord retrieves details about a specific DNS record by RRID. If there
+// is no such record, the error matches ErrRecordNotFound.
 func (c *Client) GetRecord(rrid int) (Record, error) {
+	return c.GetRecordContext(context.Background(), rrid)
+}
+
+// GetRecordContext is like GetRecord but uses ctx for the underlying request.
+func (c *Client) GetRecordContext(ctx context.Context, rrid int) (Record, error) {
 	endpoint := fmt.Sprintf("/dns/rr/%d", rrid)
-	respBody, err := c.Request("GET", endpoint, nil, nil)
+	header, respBody, err := c.request(ctx, "GET", endpoint, nil, nil)
 	if err != nil {
-		return Record{}, err
+		return Record{}, recordNotFound(rrid, err)
 	}
 
-	var response struct {
-		Response Record `json:"response"`
-	}
+	var response recordResponse
 
-	err = json.Unmarshal(respBody, &response)
-	if err != nil {
-		return Record{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	if _, err := c.decodeResponse(respBody, &response); err != nil {
+		return Record{}, err
 	}
 
-	return response.Response, nil
+	response.Response.ETag = header.Get("ETag")
+	return c.finishRecord(response.Response), nil
 }
 
-// CreateRecord creates a new DNS record.
+// CreateRecord creates a new DNS record. The created record is returned as
+// sent back by the API, or as sent if the API answered without a body.
 func (c *Client) CreateRecord(record Record) (Record, error) {
-	respBody, err := c.Request("POST", "/dns/rr", record, nil)
+	return c.CreateRecordContext(context.Background(), record)
+}
+
+// CreateRecordContext is like CreateRecord but uses ctx for the underlying request.
+func (c *Client) CreateRecordContext(ctx context.Context, record Record) (Record, error) {
+	record, err := c.prepareRecord(record)
 	if err != nil {
 		return Record{}, err
 	}
-
-	var response struct {
-		Response Record `json:"response"`
+	respBody, reqErr := c.RequestContext(ctx, "POST", "/dns/rr", record, nil)
+	if reqErr != nil && !errors.Is(reqErr, ErrDryRun) {
+		return Record{}, reqErr
 	}
 
-	err = json.Unmarshal(respBody, &response)
+	var response recordResponse
+
+	ok, err := c.decodeResponse(respBody, &response)
 	if err != nil {
-		return Record{}, fmt.Errorf("failed to unmarshal response: %w", err)
+		return Record{}, err
+	}
+	if !ok {
+		response.Response = record
 	}
 
-	return response.Response, nil
+	return c.finishRecord(response.Response), reqErr
 }
 
-// UpdateRecord updates a DNS record by the records' name
+// UpdateRecord updates a DNS record by the records' name. Like
+// CreateRecord, it returns the record as sent if the API answered without
+// a body.
 func (c *Client) UpdateRecord(record Record) (Record, error) {
-	endpoint := fmt.Sprintf("/dns/rr")
-	respBody, err := c.Request("PATCH", endpoint, record, nil)
+	return c.UpdateRecordContext(context.Background(), record)
+}
+
+// UpdateRecordContext is like UpdateRecord but uses ctx for the underlying request.
+func (c *Client) UpdateRecordContext(ctx context.Context, record Record) (Record, error) {
+	record, err := c.prepareRecord(record)
 	if err != nil {
 		return Record{}, err
 	}
-
-	var response struct {
-		Response Record `json:"response"`
+	endpoint := fmt.Sprintf("/dns/rr")
+	respBody, reqErr := c.RequestContext(ctx, "PATCH", endpoint, record, nil)
+	if reqErr != nil && !errors.Is(reqErr, ErrDryRun) {
+		return Record{}, reqErr
 	}
 
-	err = json.Unmarshal(respBody, &response)
+	var response recordResponse
+
+	ok, err := c.decodeResponse(respBody, &response)
 	if err != nil {
-		return Record{}, fmt.Errorf("failed to unmarshal response: %w", err)
+		return Record{}, err
+	}
+	if !ok {
+		response.Response = record
 	}
 
-	return response.Response, nil
+	updated := c.finishRecord(response.Response)
+	if reqErr != nil {
+		return updated, reqErr
+	}
+	return updated, c.verifyApplied(ctx, updated)
 }
 
-// UpdateRecordById updates a DNS record by RRID.
+// UpdateRecordById updates a DNS record by RRID. The record is sent as
+// given, with unset optional fields omitted; the API doesn't guarantee that
+// omitted fields keep their values. To change only some fields, use
+// UpdateRecordFields, SetRecordTTL or SetRecordData, which fetch the
+// current record first.
 func (c *Client) UpdateRecordById(rrid int, record Record) (Record, error) {
-	endpoint := fmt.Sprintf("/dns/rr/%d", rrid)
-	respBody, err := c.Request("PATCH", endpoint, record, nil)
+	return c.UpdateRecordByIdContext(context.Background(), rrid, record)
+}
+
+// UpdateRecordByIdContext is like UpdateRecordById but uses ctx for the underlying request.
+func (c *Client) UpdateRecordByIdContext(ctx context.Context, rrid int, record Record) (Record, error) {
+	record, err := c.prepareRecord(record)
 	if err != nil {
 		return Record{}, err
 	}
+	sent := record
+	sent.ID = rrid
+	updated, err := c.patchRecord(ctx, rrid, record, sent)
+	if err != nil {
+		return updated, err
+	}
+	return updated, c.verifyApplied(ctx, updated)
+}
 
-	var response struct {
-		Response Record `json:"response"`
+// patchRecord sends body, a prepared record or its JSON form, as the update
+// of the record rrid and returns the updated record, or sent if the API
+// answered without a body.
+func (c *Client) patchRecord(ctx context.Context, rrid int, body interface{}, sent Record) (Record, error) {
+	endpoint := fmt.Sprintf("/dns/rr/%d", rrid)
+	header, respBody, reqErr := c.request(ctx, "PATCH", endpoint, body, nil)
+	if reqErr != nil && !errors.Is(reqErr, ErrDryRun) {
+		return Record{}, recordNotFound(rrid, reqErr)
 	}
 
-	err = json.Unmarshal(respBody, &response)
+	var response recordResponse
+
+	ok, err := c.decodeResponse(respBody, &response)
 	if err != nil {
-		return Record{}, fmt.Errorf("failed to unmarshal response: %w", err)
+		return Record{}, err
 	}
+	if !ok {
+		response.Response = sent
+	}
+
+	response.Response.ETag = header.Get("ETag")
+	return c.finishRecord(response.Response), reqErr
+}
 
-	return response.Response, nil
+// DeleteRecord deletes a DNS record by RRID and returns the record as it
+// was before the deletion, e.g. for an audit log. The record is fetched
+// first, which costs an extra request; if that fails, nothing is deleted.
+// If only the delete fails, the fetched record is returned with the error.
+func (c *Client) DeleteRecord(rrid int) (Record, error) {
+	return c.DeleteRecordContext(context.Background(), rrid)
 }
 
-// DeleteRecord deletes a DNS record by RRID.
-func (c *Client) DeleteRecord(rrid int) error {
+// DeleteRecordContext is like DeleteRecord but uses ctx for the underlying requests.
+func (c *Client) DeleteRecordContext(ctx context.Context, rrid int) (Record, error) {
+	rec, err := c.GetRecordContext(ctx, rrid)
+	if err != nil {
+		return Record{}, err
+	}
+	return rec, c.deleteRecord(ctx, rrid)
+}
+
+// deleteRecord deletes the record rrid without fetching it first, for
+// callers that already have it.
+func (c *Client) deleteRecord(ctx context.Context, rrid int) error {
 	endpoint := fmt.Sprintf("/dns/rr/%d", rrid)
-	_, err := c.Request("DELETE", endpoint, nil, nil)
-	return err
+	_, err := c.RequestContext(ctx, "DELETE", endpoint, nil, nil)
+	return recordNotFound(rrid, err)
 }
 
-// GetRecordsByDomain retrieves all DNS records for a given domain.
+// GetRecordsByDomain retrieves all DNS records for a given domain. If the
+// API paginates the list, all pages are fetched.
 func (c *Client) GetRecordsByDomain(domain string) ([]Record, error) {
-	endpoint := fmt.Sprintf("/dns/%s/rr", domain)
-	respBody, err := c.Request("GET", endpoint, nil, nil)
+	return c.GetRecordsByDomainContext(context.Background(), domain)
+}
+
+// GetRecordsByDomainContext is like GetRecordsByDomain but uses ctx for the underlying request.
+func (c *Client) GetRecordsByDomainContext(ctx context.Context, domain string) ([]Record, error) {
+	asciiDomain, err := ToASCII(domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+	endpoint := fmt.Sprintf("/dns/%s/rr", asciiDomain)
+
+	var records []Record
+	err = c.getPages(ctx, endpoint, func(raw json.RawMessage) error {
+		var page []Record
+		if err := c.unmarshal(raw, &page); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		for _, r := range page {
+			r.Zone = domain
+			records = append(records, c.finishRecord(r))
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return records, nil
+}
 
-	var response struct {
-		Response []Record `json:"response"`
+// envelope holds the fields besides "response" that the API wraps
+// successful payloads in.
+type envelope struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// recordResponse is the reply to requests for a single record.
+type recordResponse struct {
+	envelope
+	Response Record `json:"response"`
+}
+
+// decodeResponse decodes the JSON response body into v and reports
+// whether there was a body. An empty body, as sent with 204 No Content,
+// leaves v unchanged and is not an error.
+func (c *Client) decodeResponse(body []byte, v interface{}) (bool, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return false, nil
 	}
+	if err := c.unmarshal(body, v); err != nil {
+		return true, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return true, nil
+}
 
-	err = json.Unmarshal(respBody, &response)
+// unmarshal is json.Unmarshal, except that it rejects fields v doesn't
+// model when StrictDecoding is set.
+func (c *Client) unmarshal(data []byte, v interface{}) error {
+	if !c.StrictDecoding {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("unexpected data after top-level value")
+	}
+	return nil
+}
+
+// prepareRecord applies the client-side checks and conversions configured on
+// c to a record about to be sent to the API.
+func (c *Client) prepareRecord(record Record) (Record, error) {
+	name, err := ToASCII(record.Name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return Record{}, &ValidationError{Field: "name", Message: err.Error()}
+	}
+	record.Name = name
+	record.Type = string(RecordType(record.Type).Canonical())
+	if c.QualifyNames && record.Name != "" {
+		record.Name = fqdn(record.Name)
+	}
+	record.Data = normalizeIP(record.Type, record.Data)
+
+	if c.ValidateRecords {
+		if err := record.Validate(); err != nil {
+			return Record{}, err
+		}
 	}
+	return record, nil
+}
 
-	return response.Response, nil
+// finishRecord applies the conversions configured on c to a record received
+// from the API.
+func (c *Client) finishRecord(record Record) Record {
+	if c.DecodeIDN {
+		if name, err := ToUnicode(record.Name); err == nil {
+			record.Name = name
+		}
+	}
+	return record
 }
@@ -1,9 +1,15 @@
 package regfishapi
 
 import (
+	"compress/gzip"
+	"context"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/assert"
@@ -27,7 +33,7 @@ func TestNewClient(t *testing.T) {
 				Name: "go-client-test1.example.com.",
 				Type: "A",
 				Data: "10.2.3.4",
-				TTL:  60,
+				TTL:  IntPtr(60),
 			}
 			_, err := client.CreateRecord(record)
 			assert.Nil(t, err)
@@ -41,7 +47,7 @@ func TestNewClient(t *testing.T) {
 				Name: "go-client-test1.example.com.",
 				Type: "A",
 				Data: "10.2.3.5",
-				TTL:  61,
+				TTL:  IntPtr(61),
 			}
 			res, err := client.UpdateRecord(record)
 			RecordID = res.ID
@@ -50,8 +56,163 @@ func TestNewClient(t *testing.T) {
 
 		t.Run("Delete an existing record", func(t *testing.T) {
 			// Delete an existing record
-			err := client.DeleteRecord(RecordID)
+			_, err := client.DeleteRecord(RecordID)
 			assert.Nil(t, err)
 		})
 	})
 }
+
+// newTestClient returns a Client pointed at an httptest server serving h.
+func newTestClient(t *testing.T, h http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	return NewClient("test-key", append([]Option{WithBaseURL(srv.URL)}, opts...)...)
+}
+
+func TestGetRecordContextDeadline(t *testing.T) {
+	done := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(done)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetRecordContext(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("server never observed the cancelled request")
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	body := `{"response":{"id":1,"data":"` + strings.Repeat("x", 1000) + `"}}`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}, WithMaxResponseBytes(512))
+
+	_, err := client.GetRecord(1)
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+	client.MaxResponseBytes = int64(len(body))
+	_, err = client.GetRecord(1)
+	assert.NoError(t, err)
+
+	client.MaxResponseBytes = -1
+	_, err = client.GetRecord(1)
+	assert.NoError(t, err)
+}
+
+func TestGzipResponse(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		if r.URL.Path == "/dns/rr/2" {
+			w.Write([]byte(`{"response":{"id":2}}`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"response":{"id":1,"data":"` + strings.Repeat("x", 1000) + `"}}`))
+		gz.Close()
+	})
+
+	rec, err := client.GetRecord(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rec.ID)
+	assert.Len(t, rec.Data, 1000)
+
+	rec, err = client.GetRecord(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rec.ID)
+
+	client.MaxResponseBytes = 512
+	_, err = client.GetRecord(1)
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+}
+
+func TestDeleteRecordReturnsRecord(t *testing.T) {
+	var methods []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"response":{"id":5,"name":"www.example.com.","type":"A","data":"192.0.2.1"}}`))
+	})
+
+	rec, err := client.DeleteRecord(5)
+	assert.Error(t, err)
+	assert.Equal(t, "www.example.com.", rec.Name)
+	assert.Equal(t, "192.0.2.1", rec.Data)
+	assert.Equal(t, []string{http.MethodGet, http.MethodDelete}, methods)
+}
+
+func TestNoContentResponses(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	rec := NewARecord("www.example.com.", "192.0.2.1", 300)
+
+	created, err := client.CreateRecord(rec)
+	assert.NoError(t, err)
+	assert.Equal(t, rec, created)
+
+	updated, err := client.UpdateRecord(rec)
+	assert.NoError(t, err)
+	assert.Equal(t, rec, updated)
+
+	updated, err = client.UpdateRecordById(5, rec)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, updated.ID)
+	assert.Equal(t, "192.0.2.1", updated.Data)
+
+	_, err = client.GetRecord(5)
+	assert.NoError(t, err)
+	_, err = client.DeleteRecord(5)
+	assert.NoError(t, err)
+
+	records, err := client.GetRecordsByDomain("example.com")
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+	_, err = client.ListDomains()
+	assert.NoError(t, err)
+	_, err = client.Do("POST", "/dns/rr", rec)
+	assert.NoError(t, err)
+	_, err = client.AccountInfo()
+	assert.NoError(t, err)
+
+	commented, err := client.SetRecordComment(5, "note")
+	assert.NoError(t, err)
+	assert.Equal(t, "note", *commented.Annotation)
+}
+
+func TestStrictDecoding(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":true,"response":{"id":5,"name":"www.example.com.","type":"A","data":"192.0.2.1","weight":10}}`))
+	}
+
+	lenient := newTestClient(t, handler)
+	rec, err := lenient.GetRecord(5)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, rec.ID)
+
+	strict := newTestClient(t, handler, WithStrictDecoding())
+	_, err = strict.GetRecord(5)
+	assert.ErrorContains(t, err, `unknown field "weight"`)
+}
+
+func TestStrictDecodingAcceptsKnownFields(t *testing.T) {
+	client := newTestClient(t, zoneHandler(testZone), WithStrictDecoding())
+	records, err := client.GetRecordsByDomain("example.com")
+	assert.NoError(t, err)
+	assert.Len(t, records, 4)
+}
@@ -0,0 +1,46 @@
+package regfishapi
+
+// Clone returns a copy of c that can be reconfigured, e.g. with a
+// different RequestTimeout or extra headers, without affecting c. The copy
+// shares the http.Client and the rate limiter of c, so requests made by
+// both count against the same limit. Its Header is a deep copy; its
+// RateLimit and LastResponse start out empty.
+func (c *Client) Clone() *Client {
+	var retryableStatusCodes []int
+	if c.RetryableStatusCodes != nil {
+		retryableStatusCodes = append([]int{}, c.RetryableStatusCodes...)
+	}
+	var verifyNameservers []string
+	if c.VerifyNameservers != nil {
+		verifyNameservers = append([]string{}, c.VerifyNameservers...)
+	}
+	return &Client{
+		BaseURL:              c.BaseURL,
+		APIKey:               c.APIKey,
+		Client:               c.Client,
+		UserAgent:            c.UserAgent,
+		RequestTimeout:       c.RequestTimeout,
+		MaxRetries:           c.MaxRetries,
+		RetryBackoff:         c.RetryBackoff,
+		MaxRetryAfter:        c.MaxRetryAfter,
+		RetryableStatusCodes: retryableStatusCodes,
+		RetryNonIdempotent:   c.RetryNonIdempotent,
+		ValidateRecords:      c.ValidateRecords,
+		DecodeIDN:            c.DecodeIDN,
+		StrictDecoding:       c.StrictDecoding,
+		QualifyNames:         c.QualifyNames,
+		DryRun:               c.DryRun,
+		MaxResponseBytes:     c.MaxResponseBytes,
+		Logger:               c.Logger,
+		OnRequest:            c.OnRequest,
+		OnResponse:           c.OnResponse,
+		Header:               c.Header.Clone(),
+		Concurrency:          c.Concurrency,
+		VerifyTimeout:        c.VerifyTimeout,
+		VerifyNameservers:    verifyNameservers,
+
+		limiter:   c.limiter,
+		lookup:    c.lookup,
+		configErr: c.configErr,
+	}
+}
@@ -0,0 +1,72 @@
+package regfishapi
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	var got http.Header
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"response":{}}`))
+	}, WithHeader("X-Team", "dns"), WithRateLimit(100, 1), WithTimeout(time.Second))
+
+	clone := c.Clone()
+	clone.RequestTimeout = 5 * time.Second
+	clone.Header.Set("X-Subsystem", "certs")
+
+	assert.Equal(t, time.Second, c.RequestTimeout)
+	assert.Empty(t, c.Header.Get("X-Subsystem"))
+	assert.Same(t, c.Client, clone.Client)
+	assert.Same(t, c.limiter, clone.limiter)
+
+	_, err := clone.GetRecord(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "certs", got.Get("X-Subsystem"))
+	assert.Equal(t, "dns", got.Get("X-Team"))
+	assert.NotZero(t, clone.LastResponse().StatusCode)
+	assert.Zero(t, c.LastResponse().StatusCode)
+}
+
+// TestCloneCopiesAllFields guards against new Client fields being
+// forgotten in Clone.
+func TestCloneCopiesAllFields(t *testing.T) {
+	c := NewClient("key", WithHeader("X-Team", "dns"), WithDebug(io.Discard))
+	c.OnRequest = func(*http.Request) {}
+	c.OnResponse = func(*http.Response, time.Duration) {}
+	c.RetryBackoff = noBackoff
+	c.RetryableStatusCodes = []int{500}
+	c.VerifyNameservers = []string{"ns1.example.net"}
+	orig := reflect.ValueOf(c).Elem()
+	for i := 0; i < orig.NumField(); i++ {
+		switch v := orig.Field(i); v.Kind() {
+		case reflect.String:
+			v.SetString("x")
+		case reflect.Int, reflect.Int64:
+			v.SetInt(7)
+		case reflect.Bool:
+			v.SetBool(true)
+		}
+	}
+
+	copied := reflect.ValueOf(c.Clone()).Elem()
+	for i := 0; i < orig.NumField(); i++ {
+		f := orig.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		assert.False(t, orig.Field(i).IsZero(), "test must set %s", f.Name)
+		switch f.Type.Kind() {
+		case reflect.Func:
+			assert.Equal(t, orig.Field(i).Pointer(), copied.Field(i).Pointer(), f.Name)
+		default:
+			assert.Equal(t, orig.Field(i).Interface(), copied.Field(i).Interface(), f.Name)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClientConcurrentUse shares one Client between many goroutines. Run
+// with -race to check for data races.
+func TestClientConcurrentUse(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "10")
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(testZone))
+		default:
+			var rec Record
+			json.NewDecoder(r.Body).Decode(&rec)
+			json.NewEncoder(w).Encode(map[string]Record{"response": rec})
+		}
+	}, WithRateLimit(10000, 100), WithValidation(), WithUnicodeNames())
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines*3)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("h%d.example.com.", i)
+			if _, err := client.CreateRecord(NewARecord(name, "192.0.2.1", 300)); err != nil {
+				errs <- err
+			}
+			if _, err := client.FindRecords("example.com", "www.example.com", "A"); err != nil {
+				errs <- err
+			}
+			if _, err := client.UpdateRecordById(i, NewARecord(name, "192.0.2.2", 300)); err != nil {
+				errs <- err
+			}
+			_ = client.RateLimit()
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 10, client.RateLimit().Remaining)
+}
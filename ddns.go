@@ -0,0 +1,104 @@
+package regfishapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// DefaultPublicIPEndpoint is the service DetectPublicIP queries when no
+// endpoint is given. It answers with the caller's address as plain text.
+const DefaultPublicIPEndpoint = "https://api.ipify.org"
+
+// UpdateDynamicRecord makes the A or AAAA record name of domain point at
+// ip, using the record type matching the address family of ip. The record
+// is only updated if its current data differs and is created if it
+// doesn't exist. The result reports whether a change was made. An empty
+// name, "@" and domain refer to the apex.
+func (c *Client) UpdateDynamicRecord(domain, name, ip string) (bool, error) {
+	return c.UpdateDynamicRecordContext(context.Background(), domain, name, ip)
+}
+
+// UpdateDynamicRecordContext is like UpdateDynamicRecord but uses ctx for the underlying requests.
+func (c *Client) UpdateDynamicRecordContext(ctx context.Context, domain, name, ip string) (bool, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return false, fmt.Errorf("dynamic update: invalid IP address %q", ip)
+	}
+	addr = addr.Unmap()
+	recordType := "A"
+	if addr.Is6() {
+		recordType = "AAAA"
+	}
+
+	name = apexName(name, domain)
+	existing, err := c.FindRecordsContext(ctx, domain, name, recordType)
+	if err != nil {
+		return false, err
+	}
+	switch len(existing) {
+	case 0:
+		_, err := c.CreateRecordContext(ctx, Record{Name: name, Type: recordType, Data: addr.String()})
+		return err == nil, err
+	case 1:
+		record := existing[0]
+		if current, err := netip.ParseAddr(record.Data); err == nil && current.Unmap() == addr {
+			return false, nil
+		}
+		record.Data = addr.String()
+		_, err := c.UpdateRecordByIdContext(ctx, record.ID, record)
+		return err == nil, err
+	default:
+		return false, fmt.Errorf("dynamic update %s %s: %w (%d found)", name, recordType, ErrMultipleRecords, len(existing))
+	}
+}
+
+// DetectPublicIP returns the public IP address of this host as seen by
+// endpoint, which must answer a GET request with the address as plain
+// text. An empty endpoint means DefaultPublicIPEndpoint. The request is
+// made with the client's HTTP client but without the API key, and is
+// bounded by RequestTimeout like requests to the API.
+func (c *Client) DetectPublicIP(endpoint string) (string, error) {
+	return c.DetectPublicIPContext(context.Background(), endpoint)
+}
+
+// DetectPublicIPContext is like DetectPublicIP but uses ctx for the request.
+func (c *Client) DetectPublicIPContext(ctx context.Context, endpoint string) (string, error) {
+	if endpoint == "" {
+		endpoint = DefaultPublicIPEndpoint
+	}
+	if c.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.RequestTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to detect public IP: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to detect public IP: %s returned status code %d", endpoint, resp.StatusCode)
+	}
+	addr, err := netip.ParseAddr(strings.TrimSpace(string(body)))
+	if err != nil {
+		return "", fmt.Errorf("failed to detect public IP: %s returned %q", endpoint, body)
+	}
+	return addr.Unmap().String(), nil
+}
@@ -0,0 +1,85 @@
+package regfishapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateDynamicRecord(t *testing.T) {
+	zone := newFakeZone(Record{ID: 1, Name: "home.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(60)})
+	client := newTestClient(t, zone.ServeHTTP)
+
+	changed, err := client.UpdateDynamicRecord("example.com", "home.example.com.", "192.0.2.1")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = client.UpdateDynamicRecord("example.com", "home.example.com.", "192.0.2.7")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = client.UpdateDynamicRecord("example.com", "home.example.com.", "2001:db8::7")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	recs, err := client.FindRecords("example.com", "home.example.com", "")
+	assert.NoError(t, err)
+	data := map[string]string{}
+	for _, r := range recs {
+		data[r.Type] = r.Data
+	}
+	assert.Equal(t, map[string]string{"A": "192.0.2.7", "AAAA": "2001:db8::7"}, data)
+
+	_, err = client.UpdateDynamicRecord("example.com", "home.example.com.", "not-an-ip")
+	assert.Error(t, err)
+}
+
+func TestDetectPublicIP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("X-Api-Key"))
+		w.Write([]byte("203.0.113.5\n"))
+	}))
+	defer srv.Close()
+
+	client := NewClient("secret")
+	ip, err := client.DetectPublicIP(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "203.0.113.5", ip)
+
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>"))
+	}))
+	defer bad.Close()
+	_, err = client.DetectPublicIP(bad.URL)
+	assert.Error(t, err)
+}
+
+func TestUpdateDynamicRecordApex(t *testing.T) {
+	for _, name := range []string{"", "@"} {
+		zone := newFakeZone(Record{ID: 1, Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+		client := newTestClient(t, zone.ServeHTTP)
+
+		changed, err := client.UpdateDynamicRecord("example.com", name, "192.0.2.7")
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "192.0.2.1", zone.records[1].Data, "%q", name)
+		assert.Equal(t, "example.com.", zone.records[100].Name, "%q", name)
+	}
+}
+
+func TestDetectPublicIPTimeout(t *testing.T) {
+	stall := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-stall
+	}))
+	defer srv.Close()
+	defer close(stall)
+
+	client := NewClient("secret", WithTimeout(50*time.Millisecond))
+	_, err := client.DetectPublicIP(srv.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,117 @@
+package regfishapi
+
+import (
+	"strings"
+)
+
+// Diff compares the desired state of a zone with its actual records and
+// returns the changes that converge actual to desired.
+//
+// Records are matched on name, type and data; IDs are ignored. Matched
+// records whose TTL, priority, flags or tag differ are returned in toUpdate,
+// carrying the desired values and the ID of the actual record. A nil TTL or
+// other optional field in a desired record means "don't care" and never
+// causes an update. Unmatched desired records are returned in toCreate,
+// unmatched actual records in toDelete.
+func Diff(desired, actual []Record) (toCreate, toUpdate, toDelete []Record) {
+	remaining := map[string][]Record{}
+	var order []string
+	for _, r := range actual {
+		key := recordKey(r)
+		if _, ok := remaining[key]; !ok {
+			order = append(order, key)
+		}
+		remaining[key] = append(remaining[key], r)
+	}
+
+	for _, want := range desired {
+		key := recordKey(want)
+		candidates := remaining[key]
+		if len(candidates) == 0 {
+			toCreate = append(toCreate, want)
+			continue
+		}
+		have := candidates[0]
+		remaining[key] = candidates[1:]
+		if needsUpdate(want, have) {
+			want.ID = have.ID
+			toUpdate = append(toUpdate, want)
+		}
+	}
+
+	for _, key := range order {
+		toDelete = append(toDelete, remaining[key]...)
+	}
+	return toCreate, toUpdate, toDelete
+}
+
+// EqualContent reports whether r and other describe the same record
+// content: name, type and data, compared as by Diff (so "Mail.example.com"
+// equals "mail.example.com." and "2001:DB8::1" equals "2001:db8::1"), and
+// the values of TTL, Priority, Tag and Flags, where unset only equals
+// unset. ID, Annotation, Zone and ETag are ignored.
+func (r Record) EqualContent(other Record) bool {
+	return recordKey(r) == recordKey(other) &&
+		intPtrEqual(r.TTL, other.TTL) &&
+		intPtrEqual(r.Priority, other.Priority) &&
+		intPtrEqual(r.Flags, other.Flags) &&
+		stringPtrEqual(r.Tag, other.Tag)
+}
+
+func intPtrEqual(a, b *int) bool {
+	return a == b || (a != nil && b != nil && *a == *b)
+}
+
+func stringPtrEqual(a, b *string) bool {
+	return a == b || (a != nil && b != nil && *a == *b)
+}
+
+// recordKey identifies a record by its normalized name, type and data.
+func recordKey(r Record) string {
+	return nameKey(r.Name) + "\x00" + strings.ToUpper(r.Type) + "\x00" + normalizeData(r.Type, r.Data)
+}
+
+// nameKey returns name fully qualified, in lower case and with Unicode
+// labels in punycode, so that names equal by sameName have the same key.
+func nameKey(name string) string {
+	return strings.ToLower(comparableName(name)) + "."
+}
+
+// normalizeData returns r's data in a canonical form for comparison, so
+// that e.g. "2001:DB8::1" equals "2001:db8::1" and "Mail.example.com"
+// equals "mail.example.com.".
+func normalizeData(recordType, data string) string {
+	data = strings.TrimSpace(data)
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA":
+		return normalizeIP(recordType, data)
+	case "CNAME", "NS", "PTR", "MX":
+		return strings.ToLower(fqdn(data))
+	case "SRV":
+		fields := strings.Fields(data)
+		if len(fields) == 3 {
+			fields[2] = strings.ToLower(fqdn(fields[2]))
+		}
+		return strings.Join(fields, " ")
+	case "TXT":
+		return parseTXT(data)
+	}
+	return data
+}
+
+// needsUpdate reports whether the attributes of have differ from the ones
+// specified in want.
+func needsUpdate(want, have Record) bool {
+	return intPtrDiffers(want.TTL, have.TTL) ||
+		intPtrDiffers(want.Priority, have.Priority) ||
+		intPtrDiffers(want.Flags, have.Flags) ||
+		stringPtrDiffers(want.Tag, have.Tag)
+}
+
+func intPtrDiffers(want, have *int) bool {
+	return want != nil && (have == nil || *want != *have)
+}
+
+func stringPtrDiffers(want, have *string) bool {
+	return want != nil && (have == nil || *want != *have)
+}
@@ -0,0 +1,97 @@
+package regfishapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	prio10, prio20 := 10, 20
+	actual := []Record{
+		{ID: 1, Name: "example.com.", Type: "NS", Data: "ns1.regfish.de.", TTL: IntPtr(3600)},
+		{ID: 2, Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(300)},
+		{ID: 3, Name: "www.example.com.", Type: "A", Data: "192.0.2.2", TTL: IntPtr(300)},
+		{ID: 4, Name: "example.com.", Type: "MX", Data: "mail.example.com.", TTL: IntPtr(300), Priority: &prio10},
+		{ID: 5, Name: "old.example.com.", Type: "CNAME", Data: "www.example.com."},
+	}
+	desired := []Record{
+		{Name: "example.com", Type: "ns", Data: "NS1.regfish.de"},
+		{Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(60)},
+		{Name: "www.example.com.", Type: "A", Data: "192.0.2.3", TTL: IntPtr(300)},
+		{Name: "example.com.", Type: "MX", Data: "mail.example.com.", Priority: &prio20},
+	}
+
+	toCreate, toUpdate, toDelete := Diff(desired, actual)
+
+	assert.Equal(t, []Record{{Name: "www.example.com.", Type: "A", Data: "192.0.2.3", TTL: IntPtr(300)}}, toCreate)
+	if assert.Len(t, toUpdate, 2) {
+		assert.Equal(t, 2, toUpdate[0].ID)
+		assert.Equal(t, IntPtr(60), toUpdate[0].TTL)
+		assert.Equal(t, 4, toUpdate[1].ID)
+		assert.Equal(t, 20, *toUpdate[1].Priority)
+	}
+	if assert.Len(t, toDelete, 2) {
+		assert.Equal(t, 3, toDelete[0].ID)
+		assert.Equal(t, 5, toDelete[1].ID)
+	}
+}
+
+func TestDiffDuplicates(t *testing.T) {
+	actual := []Record{
+		{ID: 1, Name: "a.example.com.", Type: "TXT", Data: `"x"`},
+		{ID: 2, Name: "a.example.com.", Type: "TXT", Data: `"x"`},
+	}
+	desired := []Record{{Name: "a.example.com.", Type: "TXT", Data: "x"}}
+
+	toCreate, toUpdate, toDelete := Diff(desired, actual)
+	assert.Empty(t, toCreate)
+	assert.Empty(t, toUpdate)
+	if assert.Len(t, toDelete, 1) {
+		assert.Equal(t, 2, toDelete[0].ID)
+	}
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	actual := []Record{{ID: 1, Name: "www.example.com.", Type: "AAAA", Data: "2001:db8::1", TTL: IntPtr(300)}}
+	desired := []Record{{Name: "www.example.com.", Type: "AAAA", Data: "2001:DB8:0::1"}}
+
+	toCreate, toUpdate, toDelete := Diff(desired, actual)
+	assert.Empty(t, toCreate)
+	assert.Empty(t, toUpdate)
+	assert.Empty(t, toDelete)
+}
+
+func TestDiffIDN(t *testing.T) {
+	actual := []Record{{ID: 1, Name: "www.xn--mller-kva.de.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(300)}}
+	desired := []Record{{Name: "www.müller.de.", Type: "A", Data: "192.0.2.1"}}
+
+	toCreate, toUpdate, toDelete := Diff(desired, actual)
+	assert.Empty(t, toCreate)
+	assert.Empty(t, toUpdate)
+	assert.Empty(t, toDelete)
+}
+
+func TestRecordEqualContent(t *testing.T) {
+	a := Record{ID: 1, Name: "Mail.example.com", Type: "mx", Data: "MX.example.com", TTL: IntPtr(300), Priority: IntPtr(10), Annotation: StringPtr("x")}
+	b := Record{ID: 2, Name: "mail.example.com.", Type: "MX", Data: "mx.example.com.", TTL: IntPtr(300), Priority: IntPtr(10)}
+	assert.True(t, a.EqualContent(b))
+	assert.True(t, b.EqualContent(a))
+
+	c := b
+	c.Priority = IntPtr(20)
+	assert.False(t, a.EqualContent(c))
+	c.Priority = nil
+	assert.False(t, a.EqualContent(c))
+	c = b
+	c.TTL = nil
+	assert.False(t, a.EqualContent(c))
+	c = b
+	c.Data = "mx2.example.com."
+	assert.False(t, a.EqualContent(c))
+
+	caa := NewCAARecord("example.com.", 0, CAATagIssue, "letsencrypt.org", 0)
+	other := NewCAARecord("example.com.", 0, CAATagIssueWild, "letsencrypt.org", 0)
+	assert.True(t, caa.EqualContent(NewCAARecord("example.com.", 0, CAATagIssue, "letsencrypt.org", 0)))
+	assert.False(t, caa.EqualContent(other))
+}
@@ -0,0 +1,45 @@
+package regfishapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Domain represents a domain held by the account.
+type Domain struct {
+	Name   string    `json:"name"`
+	Status string    `json:"status"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// ListDomains retrieves all domains of the account. If the API paginates the
+// list, all pages are fetched.
+func (c *Client) ListDomains() ([]Domain, error) {
+	return c.ListDomainsContext(context.Background())
+}
+
+// ListDomainsContext is like ListDomains but uses ctx for the underlying request.
+func (c *Client) ListDomainsContext(ctx context.Context) ([]Domain, error) {
+	var domains []Domain
+	err := c.getPages(ctx, "/domain", func(raw json.RawMessage) error {
+		var page []Domain
+		if err := c.unmarshal(raw, &page); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		for _, d := range page {
+			if c.DecodeIDN {
+				if name, err := ToUnicode(d.Name); err == nil {
+					d.Name = name
+				}
+			}
+			domains = append(domains, d)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
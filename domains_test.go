@@ -0,0 +1,28 @@
+package regfishapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListDomains(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/domain", r.URL.Path)
+		w.Write([]byte(`{"response":[
+			{"name":"example.com","status":"active","expiry":"2027-03-01T00:00:00Z"},
+			{"name":"example.org","status":"pending"}
+		]}`))
+	})
+
+	domains, err := client.ListDomains()
+	assert.NoError(t, err)
+	if assert.Len(t, domains, 2) {
+		assert.Equal(t, "example.com", domains[0].Name)
+		assert.Equal(t, "active", domains[0].Status)
+		assert.Equal(t, time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC), domains[0].Expiry)
+		assert.True(t, domains[1].Expiry.IsZero())
+	}
+}
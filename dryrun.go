@@ -0,0 +1,37 @@
+package regfishapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrDryRun is returned by mutating requests made while Client.DryRun is
+// set. It marks a result as a preview: the API was not called and nothing
+// was changed. Batch operations report it per record, so errors.Is(err,
+// ErrDryRun) holds for their aggregated errors too.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// isMutation reports whether method changes state on the server.
+func isMutation(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// dryRunResponse builds the response body returned in place of a skipped
+// request: the request payload echoed in the usual envelope, so callers
+// decode the record that would have been sent.
+func dryRunResponse(reqBody []byte) []byte {
+	payload := reqBody
+	if len(payload) == 0 {
+		payload = []byte("null")
+	}
+	resp := make([]byte, 0, len(payload)+32)
+	resp = append(resp, `{"success":true,"response":`...)
+	resp = append(resp, payload...)
+	resp = append(resp, '}')
+	return resp
+}
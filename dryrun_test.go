@@ -0,0 +1,41 @@
+package regfishapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	var methods []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.URL.Path == "/dns/rr/2" {
+			w.Write([]byte(`{"response":{"id":2,"name":"www.example.com.","type":"A","data":"192.0.2.1"}}`))
+			return
+		}
+		w.Write([]byte(testZone))
+	}, WithDryRun())
+
+	rec, err := client.CreateRecord(NewARecord("new.example.com.", "192.0.2.7", 300))
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Equal(t, "new.example.com.", rec.Name)
+	assert.Equal(t, "192.0.2.7", rec.Data)
+
+	rec, err = client.UpdateRecordById(2, NewARecord("www.example.com.", "192.0.2.8", 300))
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Equal(t, "192.0.2.8", rec.Data)
+
+	rec, err = client.DeleteRecord(2)
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Equal(t, "192.0.2.1", rec.Data)
+
+	n, err := client.DeleteRecordsByName("example.com", "www.example.com.")
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Equal(t, 0, n)
+
+	// Only the lookups made by DeleteRecord and DeleteRecordsByName reached
+	// the server.
+	assert.Equal(t, []string{http.MethodGet, http.MethodGet}, methods)
+}
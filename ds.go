@@ -0,0 +1,128 @@
+package regfishapi
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DS digest types (RFC 4034, RFC 4509, RFC 6605).
+const (
+	DSDigestSHA1   = 1
+	DSDigestSHA256 = 2
+	DSDigestSHA384 = 4
+)
+
+// dsDigestSizes maps DS digest types to the length of their digest.
+var dsDigestSizes = map[int]int{
+	DSDigestSHA1:   sha1.Size,
+	DSDigestSHA256: sha256.Size,
+	DSDigestSHA384: sha512.Size384,
+}
+
+// dnssecAlgorithms holds the DNSSEC algorithm numbers accepted in DS and
+// DNSKEY records.
+var dnssecAlgorithms = map[int]bool{
+	1: true, 3: true, 5: true, 6: true, 7: true, 8: true,
+	10: true, 12: true, 13: true, 14: true, 15: true, 16: true,
+}
+
+// DS holds the components of a DS record.
+type DS struct {
+	KeyTag     int
+	Algorithm  int
+	DigestType int
+	Digest     []byte
+}
+
+// Record returns a DS record for name described by d.
+func (d DS) Record(name string, ttl int) Record {
+	return NewDSRecord(name, d.KeyTag, d.Algorithm, d.DigestType, d.Digest, ttl)
+}
+
+// NewDSRecord returns a DS record delegating name to the key with keyTag
+// and algorithm. The digest is hex-encoded in the record. Use Validate to
+// check the parameters.
+func NewDSRecord(name string, keyTag, algorithm, digestType int, digest []byte, ttl int) Record {
+	return Record{
+		Name: name,
+		Type: "DS",
+		Data: fmt.Sprintf("%d %d %d %s", keyTag, algorithm, digestType, strings.ToUpper(hex.EncodeToString(digest))),
+		TTL:  optionalTTL(ttl),
+	}
+}
+
+// ParseDS splits a DS record into its components. The record data must be
+// of the form "key-tag algorithm digest-type digest"; a digest split into
+// several hex strings is accepted.
+func ParseDS(r Record) (DS, error) {
+	if !strings.EqualFold(r.Type, "DS") {
+		return DS{}, fmt.Errorf("not a DS record: %s", r.Type)
+	}
+
+	fields := strings.Fields(r.Data)
+	if len(fields) < 4 {
+		return DS{}, fmt.Errorf("DS data %q is not of the form \"key-tag algorithm digest-type digest\"", r.Data)
+	}
+	var ds DS
+	var err error
+	if ds.KeyTag, err = parseUint16(fields[0]); err != nil {
+		return DS{}, fmt.Errorf("DS key tag: %w", err)
+	}
+	if ds.Algorithm, err = strconv.Atoi(fields[1]); err != nil {
+		return DS{}, fmt.Errorf("DS algorithm %q is not a number", fields[1])
+	}
+	if ds.DigestType, err = strconv.Atoi(fields[2]); err != nil {
+		return DS{}, fmt.Errorf("DS digest type %q is not a number", fields[2])
+	}
+	if ds.Digest, err = hex.DecodeString(strings.Join(fields[3:], "")); err != nil {
+		return DS{}, fmt.Errorf("DS digest is not hex: %w", err)
+	}
+	return ds, nil
+}
+
+// validateDS checks the data of a DS record.
+func validateDS(r Record) error {
+	ds, err := ParseDS(r)
+	if err != nil {
+		return &ValidationError{Field: "data", Message: err.Error()}
+	}
+	if !dnssecAlgorithms[ds.Algorithm] {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("unknown DNSSEC algorithm %d", ds.Algorithm)}
+	}
+	size, ok := dsDigestSizes[ds.DigestType]
+	if !ok {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("unknown DS digest type %d", ds.DigestType)}
+	}
+	if len(ds.Digest) != size {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("digest must be %d bytes for digest type %d, got %d", size, ds.DigestType, len(ds.Digest))}
+	}
+	return nil
+}
+
+// validateDNSKEY checks the data of a DNSKEY record, which must be of the
+// form "flags protocol algorithm public-key".
+func validateDNSKEY(r Record) error {
+	fields := strings.Fields(r.Data)
+	if len(fields) < 4 {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("%q is not of the form \"flags protocol algorithm public-key\"", r.Data)}
+	}
+	if _, err := parseUint16(fields[0]); err != nil {
+		return &ValidationError{Field: "data", Message: "flags: " + err.Error()}
+	}
+	if fields[1] != "3" {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("protocol must be 3, got %q", fields[1])}
+	}
+	if alg, err := strconv.Atoi(fields[2]); err != nil || !dnssecAlgorithms[alg] {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("unknown DNSSEC algorithm %q", fields[2])}
+	}
+	if _, err := base64.StdEncoding.DecodeString(strings.Join(fields[3:], "")); err != nil {
+		return &ValidationError{Field: "data", Message: "public key is not base64"}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package regfishapi
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDSRecord(t *testing.T) {
+	digest := sha256.Sum256([]byte("dnskey"))
+	rec := NewDSRecord("sub.example.com.", 12345, 13, DSDigestSHA256, digest[:], 3600)
+	assert.Equal(t, "DS", rec.Type)
+	assert.NoError(t, rec.Validate())
+
+	ds, err := ParseDS(rec)
+	assert.NoError(t, err)
+	assert.Equal(t, DS{KeyTag: 12345, Algorithm: 13, DigestType: 2, Digest: digest[:]}, ds)
+	assert.Equal(t, rec, ds.Record("sub.example.com.", 3600))
+
+	assert.Error(t, NewDSRecord("sub.example.com.", 12345, 4, 2, digest[:], 0).Validate())
+	assert.Error(t, NewDSRecord("sub.example.com.", 12345, 13, 3, digest[:], 0).Validate())
+	assert.Error(t, NewDSRecord("sub.example.com.", 12345, 13, 1, digest[:], 0).Validate())
+	assert.Error(t, NewDSRecord("sub.example.com.", 70000, 13, 2, digest[:], 0).Validate())
+}
+
+func TestParseDS(t *testing.T) {
+	ds, err := ParseDS(Record{Type: "ds", Data: "2371 13 2 1F987CC6583E9290 8C2B1F0B6F4D5BDD 66D6E5FF361B0DD3 C3E893ECDE187722"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2371, ds.KeyTag)
+	assert.Len(t, ds.Digest, 32)
+
+	_, err = ParseDS(Record{Type: "A", Data: "192.0.2.1"})
+	assert.Error(t, err)
+	_, err = ParseDS(Record{Type: "DS", Data: "2371 13 2 zz"})
+	assert.Error(t, err)
+}
+
+func TestValidateDNSKEY(t *testing.T) {
+	rec := Record{Name: "example.com.", Type: "DNSKEY", Data: "257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="}
+	assert.NoError(t, rec.Validate())
+
+	rec.Data = "257 2 13 AAAA"
+	assert.Error(t, rec.Validate())
+	rec.Data = "257 3 13 !!"
+	assert.Error(t, rec.Validate())
+}
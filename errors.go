@@ -0,0 +1,127 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matched by *APIError, for use with errors.Is.
+var (
+	// ErrUnauthorized matches responses with status 401 or 403, which the
+	// API sends when the API key is missing, invalid or lacks permission.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound matches responses with status 404.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict matches responses with status 409 or 412, which mean
+	// that the resource changed concurrently, e.g. because the If-Match
+	// condition of UpdateRecordByIdIfMatch didn't hold.
+	ErrConflict = errors.New("conflict")
+)
+
+// APIError is returned by Request when the Regfish API answers with a status
+// code of 400 or above. Use errors.As to inspect it.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"-"`
+	// Message is the "message" field of the error payload, if any.
+	Message string `json:"message"`
+	// Reason is the "error" field of the error payload, if any.
+	Reason string `json:"error"`
+	// Body holds the raw response body.
+	Body []byte `json:"-"`
+	// RequestID is the X-Request-Id header of the response, if any.
+	RequestID string `json:"-"`
+	// Errors holds the field-level details of the "errors" array of the
+	// error payload, if any, e.g. which record field the API rejected.
+	Errors []ValidationError `json:"errors"`
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("request failed with status code %d", e.StatusCode)
+	switch {
+	case e.Message != "" && e.Reason != "":
+		msg += fmt.Sprintf(": %s (%s)", e.Message, e.Reason)
+	case e.Message != "":
+		msg += ": " + e.Message
+	case e.Reason != "":
+		msg += ": " + e.Reason
+	}
+	for _, fe := range e.Errors {
+		msg += fmt.Sprintf("; %s: %s", fe.Field, fe.Message)
+	}
+	return msg
+}
+
+// String renders e in key=value form for logs, e.g.
+// `status=404 message="record not found" request_id=abc`, leaving out
+// empty fields.
+func (e *APIError) String() string {
+	s := fmt.Sprintf("status=%d", e.StatusCode)
+	for _, kv := range [][2]string{{"message", e.Message}, {"reason", e.Reason}, {"request_id", e.RequestID}} {
+		if kv[1] != "" {
+			s += fmt.Sprintf(" %s=%q", kv[0], kv[1])
+		}
+	}
+	for _, fe := range e.Errors {
+		s += fmt.Sprintf(" %s=%q", "error."+fe.Field, fe.Message)
+	}
+	return s
+}
+
+// Unwrap returns the field errors as *ValidationError, so errors.As finds
+// the first one just like for a record rejected by Record.Validate.
+func (e *APIError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i := range e.Errors {
+		errs[i] = &e.Errors[i]
+	}
+	return errs
+}
+
+// Is reports whether e corresponds to target, one of the sentinel errors
+// declared in this package.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || e.StatusCode == http.StatusPreconditionFailed
+	}
+	return false
+}
+
+// newAPIError builds an APIError from a failed response. The body is decoded
+// on a best-effort basis; a body that isn't JSON is kept only in Body.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Body: body}
+	if len(body) > 0 {
+		_ = json.Unmarshal(body, apiErr)
+	}
+	return apiErr
+}
+
+// ErrRecordNotFound is returned when a requested record doesn't exist. It
+// wraps ErrNotFound, so errors.Is(err, ErrNotFound) holds as well.
+var ErrRecordNotFound = fmt.Errorf("record %w", ErrNotFound)
+
+// recordNotFound marks a 404 from a request for record rrid with
+// ErrRecordNotFound. Other errors are returned unchanged.
+func recordNotFound(rrid int, err error) error {
+	if err == nil || !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return fmt.Errorf("%w: rrid %d: %w", ErrRecordNotFound, rrid, err)
+}
+
+// ErrMultipleRecords is returned when an operation expects a single record
+// for a name and type but the zone holds several, e.g. round-robin A records.
+var ErrMultipleRecords = errors.New("multiple records match")
+
+// ErrResponseTooLarge is returned when a response body exceeds the Client's
+// MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("response body too large")
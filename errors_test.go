@@ -0,0 +1,114 @@
+package regfishapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success":false,"message":"invalid record data","error":"bad_request"}`))
+	})
+
+	_, err := client.GetRecord(1)
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		assert.Equal(t, "invalid record data", apiErr.Message)
+		assert.Equal(t, "bad_request", apiErr.Reason)
+	}
+	assert.EqualError(t, err, "GET /dns/rr/1: request failed with status code 400: invalid record data (bad_request)")
+}
+
+func TestAPIErrorNonJSONBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>bad gateway</html>"))
+	})
+
+	_, err := client.GetRecord(1)
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		assert.Empty(t, apiErr.Message)
+		assert.Equal(t, "<html>bad gateway</html>", string(apiErr.Body))
+	}
+	assert.EqualError(t, err, "GET /dns/rr/1: request failed with status code 502")
+}
+
+func TestErrorIncludesEndpoint(t *testing.T) {
+	client := NewClient(secretKey, WithBaseURL("http://127.0.0.1:1"))
+
+	_, err := client.DeleteRecord(7)
+	assert.ErrorContains(t, err, "GET /dns/rr/7: failed to make request")
+	assert.NotContains(t, err.Error(), secretKey)
+}
+
+func TestAPIErrorSentinels(t *testing.T) {
+	for code, want := range map[int]error{
+		http.StatusUnauthorized:       ErrUnauthorized,
+		http.StatusForbidden:          ErrUnauthorized,
+		http.StatusNotFound:           ErrNotFound,
+		http.StatusConflict:           ErrConflict,
+		http.StatusPreconditionFailed: ErrConflict,
+	} {
+		err := error(&APIError{StatusCode: code})
+		assert.ErrorIs(t, err, want, "status %d", code)
+	}
+	assert.NotErrorIs(t, &APIError{StatusCode: 500}, ErrUnauthorized)
+	assert.NotErrorIs(t, &APIError{StatusCode: 500}, ErrNotFound)
+}
+
+func TestRecordNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"record not found"}`))
+	})
+
+	_, err := client.GetRecord(42)
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorContains(t, err, "rrid 42")
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+
+	_, err = client.UpdateRecordById(42, Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+
+	_, err = client.DeleteRecord(42)
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+	assert.NotErrorIs(t, recordNotFound(1, &APIError{StatusCode: 500}), ErrRecordNotFound)
+}
+
+func TestAPIErrorFieldErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message":"invalid record","errors":[{"field":"data","message":"not an IPv4 address"},{"field":"ttl","message":"too low"}]}`))
+	})
+
+	_, err := client.CreateRecord(NewARecord("www.example.com.", "192.0.2.300", 0))
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, []ValidationError{
+			{Field: "data", Message: "not an IPv4 address"},
+			{Field: "ttl", Message: "too low"},
+		}, apiErr.Errors)
+	}
+	var verr *ValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, "data", verr.Field)
+	}
+	assert.EqualError(t, err, "POST /dns/rr: request failed with status code 422: invalid record; data: not an IPv4 address; ttl: too low")
+}
+
+func TestAPIErrorString(t *testing.T) {
+	err := &APIError{StatusCode: 404, Message: "record not found", RequestID: "abc"}
+	assert.Equal(t, `status=404 message="record not found" request_id="abc"`, err.String())
+
+	err = &APIError{StatusCode: 422, Reason: "invalid", Errors: []ValidationError{{Field: "data", Message: "bad address"}}}
+	assert.Equal(t, `status=422 reason="invalid" error.data="bad address"`, err.String())
+}
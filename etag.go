@@ -0,0 +1,23 @@
+package regfishapi
+
+import "context"
+
+// UpdateRecordByIdIfMatch is like UpdateRecordById but only applies the
+// update if the record still has the entity tag etag, as returned in
+// Record.ETag by GetRecord. If the record changed in the meantime, the
+// error matches ErrConflict. An empty etag makes it behave like
+// UpdateRecordById.
+//
+// This relies on the API sending ETags and honouring If-Match; if it
+// doesn't, etag is empty and updates are unconditional.
+func (c *Client) UpdateRecordByIdIfMatch(rrid int, record Record, etag string) (Record, error) {
+	return c.UpdateRecordByIdIfMatchContext(context.Background(), rrid, record, etag)
+}
+
+// UpdateRecordByIdIfMatchContext is like UpdateRecordByIdIfMatch but uses ctx for the underlying request.
+func (c *Client) UpdateRecordByIdIfMatchContext(ctx context.Context, rrid int, record Record, etag string) (Record, error) {
+	if etag != "" {
+		ctx = ContextWithHeader(ctx, "If-Match", etag)
+	}
+	return c.UpdateRecordByIdContext(ctx, rrid, record)
+}
@@ -0,0 +1,38 @@
+package regfishapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateRecordByIdIfMatch(t *testing.T) {
+	current := `"v1"`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			if m := r.Header.Get("If-Match"); m != "" && m != current {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			current = `"v2"`
+		}
+		w.Header().Set("ETag", current)
+		w.Write([]byte(`{"response":{"id":1,"name":"www.example.com.","type":"A","data":"192.0.2.1"}}`))
+	})
+
+	rec, err := client.GetRecord(1)
+	assert.NoError(t, err)
+	assert.Equal(t, `"v1"`, rec.ETag)
+
+	rec.Data = "192.0.2.2"
+	updated, err := client.UpdateRecordByIdIfMatch(1, rec, rec.ETag)
+	assert.NoError(t, err)
+	assert.Equal(t, `"v2"`, updated.ETag)
+
+	_, err = client.UpdateRecordByIdIfMatch(1, rec, rec.ETag)
+	assert.ErrorIs(t, err, ErrConflict)
+
+	_, err = client.UpdateRecordByIdIfMatch(1, rec, "")
+	assert.NoError(t, err)
+}
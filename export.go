@@ -0,0 +1,102 @@
+package regfishapi
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// CSVHeader is the header row written by WriteRecordsCSV.
+var CSVHeader = []string{"zone", "name", "type", "data", "ttl", "priority", "flags", "tag", "annotation", "id"}
+
+// ExportAll returns the records of every domain of the account, e.g. for a
+// backup. Each record has its Zone set. Domains are fetched concurrently,
+// bounded by Client.Concurrency, and the result is ordered by domain as
+// returned by ListDomains. If some domains fail, the records of the others
+// are returned together with the joined errors.
+func (c *Client) ExportAll() ([]Record, error) {
+	return c.ExportAllContext(context.Background())
+}
+
+// ExportAllContext is like ExportAll but uses ctx for the underlying requests.
+func (c *Client) ExportAllContext(ctx context.Context) ([]Record, error) {
+	domains, err := c.ListDomainsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	perDomain := make([][]Record, len(domains))
+	errs := make([]error, len(domains))
+	started, stopErr := c.forEach(ctx, len(domains), func(i int) {
+		records, err := c.GetRecordsByDomainContext(ctx, domains[i].Name)
+		if err != nil {
+			err = fmt.Errorf("export %s: %w", domains[i].Name, err)
+		}
+		perDomain[i], errs[i] = records, err
+	})
+
+	var all []Record
+	for _, records := range perDomain[:started] {
+		all = append(all, records...)
+	}
+	return all, joinStopErr(stopErr, errors.Join(errs[:started]...))
+}
+
+// exportedRecord is the JSON form of a record written by WriteRecordsJSON,
+// which includes the zone.
+type exportedRecord struct {
+	Zone string `json:"zone"`
+	Record
+}
+
+// WriteRecordsJSON writes records as an indented JSON array to w. Unlike
+// the API representation, every element includes the record's Zone.
+func WriteRecordsJSON(w io.Writer, records []Record) error {
+	out := make([]exportedRecord, len(records))
+	for i, r := range records {
+		out[i] = exportedRecord{Zone: r.Zone, Record: r}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// WriteRecordsCSV writes records to w as CSV with the columns of CSVHeader,
+// starting with the header row. Unset optional fields are left empty.
+func WriteRecordsCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CSVHeader); err != nil {
+		return err
+	}
+	for _, r := range records {
+		row := []string{
+			r.Zone, r.Name, r.Type, r.Data,
+			csvInt(r.TTL), csvInt(r.Priority), csvInt(r.Flags),
+			csvString(r.Tag), csvString(r.Annotation),
+			strconv.Itoa(r.ID),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func csvString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,59 @@
+package regfishapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func exportHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/domain":
+		w.Write([]byte(`{"response":[{"name":"example.com"},{"name":"example.org"},{"name":"broken.net"}]}`))
+	case "/dns/example.com/rr":
+		w.Write([]byte(`{"response":[{"id":1,"name":"www.example.com.","type":"A","data":"192.0.2.1","ttl":300}]}`))
+	case "/dns/example.org/rr":
+		w.Write([]byte(`{"response":[{"id":2,"name":"example.org.","type":"MX","data":"mail.example.org.","priority":10}]}`))
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func TestExportAll(t *testing.T) {
+	client := newTestClient(t, exportHandler)
+
+	records, err := client.ExportAll()
+	assert.ErrorContains(t, err, "export broken.net")
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "example.com", records[0].Zone)
+		assert.Equal(t, "example.org", records[1].Zone)
+	}
+}
+
+func TestWriteRecordsJSON(t *testing.T) {
+	records := []Record{{ID: 1, Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(300), Zone: "example.com"}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteRecordsJSON(&buf, records))
+	var out []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, []map[string]interface{}{{
+		"zone": "example.com", "id": 1.0, "name": "www.example.com.", "type": "A", "data": "192.0.2.1", "ttl": 300.0,
+	}}, out)
+}
+
+func TestWriteRecordsCSV(t *testing.T) {
+	records := []Record{
+		{ID: 1, Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(300), Zone: "example.com"},
+		{ID: 2, Name: "example.com.", Type: "TXT", Data: `"v=spf1 -all"`, Annotation: StringPtr("mail, spf"), Zone: "example.com"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteRecordsCSV(&buf, records))
+	assert.Equal(t, "zone,name,type,data,ttl,priority,flags,tag,annotation,id\n"+
+		"example.com,www.example.com.,A,192.0.2.1,300,,,,,1\n"+
+		`example.com,example.com.,TXT,"""v=spf1 -all""",,,,,"mail, spf",2`+"\n", buf.String())
+}
@@ -0,0 +1,341 @@
+package regfishapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// FindRecords returns the records of domain matching name and recordType.
+// An empty name or recordType matches any value. Names are compared
+// case-insensitively and with or without the trailing dot, so
+// "www.example.com" matches "www.example.com.". Use "@" or domain for the
+// records at the apex.
+func (c *Client) FindRecords(domain, name, recordType string) ([]Record, error) {
+	return c.FindRecordsContext(context.Background(), domain, name, recordType)
+}
+
+// FindRecordsContext is like FindRecords but uses ctx for the underlying request.
+func (c *Client) FindRecordsContext(ctx context.Context, domain, name, recordType string) ([]Record, error) {
+	if name != "" {
+		name = apexName(name, domain)
+	}
+	records, err := c.GetRecordsByDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	return filterRecords(records, name, recordType), nil
+}
+
+// GetRecordByName returns the single record of domain matching name and
+// recordType, compared as in FindRecords, except that an empty name refers
+// to the apex like "@" and domain do. If no record matches, the error
+// matches ErrRecordNotFound; if several do, it matches ErrMultipleRecords.
+func (c *Client) GetRecordByName(domain, name, recordType string) (Record, error) {
+	return c.GetRecordByNameContext(context.Background(), domain, name, recordType)
+}
+
+// GetRecordByNameContext is like GetRecordByName but uses ctx for the underlying request.
+func (c *Client) GetRecordByNameContext(ctx context.Context, domain, name, recordType string) (Record, error) {
+	return c.findOne(ctx, domain, apexName(name, domain), recordType)
+}
+
+// GetRecordsByAnnotation returns the records of domain whose Annotation
+// is exactly annotation. Records without an annotation never match.
+func (c *Client) GetRecordsByAnnotation(domain, annotation string) ([]Record, error) {
+	return c.GetRecordsByAnnotationContext(context.Background(), domain, annotation)
+}
+
+// GetRecordsByAnnotationContext is like GetRecordsByAnnotation but uses ctx for the underlying request.
+func (c *Client) GetRecordsByAnnotationContext(ctx context.Context, domain, annotation string) ([]Record, error) {
+	records, err := c.GetRecordsByDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	var matches []Record
+	for _, r := range records {
+		if r.Annotation != nil && *r.Annotation == annotation {
+			matches = append(matches, r)
+		}
+	}
+	return matches, nil
+}
+
+// GetRecordsByTag returns the records of domain whose Tag is exactly tag,
+// e.g. to manage the records created by one pipeline as a set. Note that
+// the API also uses Tag for the property tag of CAA records.
+func (c *Client) GetRecordsByTag(domain, tag string) ([]Record, error) {
+	return c.GetRecordsByTagContext(context.Background(), domain, tag)
+}
+
+// GetRecordsByTagContext is like GetRecordsByTag but uses ctx for the underlying request.
+func (c *Client) GetRecordsByTagContext(ctx context.Context, domain, tag string) ([]Record, error) {
+	records, err := c.GetRecordsByDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	var matches []Record
+	for _, r := range records {
+		if r.Tag != nil && *r.Tag == tag {
+			matches = append(matches, r)
+		}
+	}
+	return matches, nil
+}
+
+// findOne returns the single record of domain matching name and
+// recordType. It fails with ErrRecordNotFound if there is none and with
+// ErrMultipleRecords if the match is ambiguous.
+func (c *Client) findOne(ctx context.Context, domain, name, recordType string) (Record, error) {
+	records, err := c.FindRecordsContext(ctx, domain, name, recordType)
+	if err != nil {
+		return Record{}, err
+	}
+	switch len(records) {
+	case 0:
+		return Record{}, fmt.Errorf("%s %s: %w", name, recordType, ErrRecordNotFound)
+	case 1:
+		return records[0], nil
+	default:
+		return Record{}, fmt.Errorf("%s %s: %w (%d found)", name, recordType, ErrMultipleRecords, len(records))
+	}
+}
+
+// GetRecordsByDomainFiltered retrieves the records of domain whose type is
+// one of types, compared case-insensitively. With no types, all records are
+// returned. See GetRecordsByFilter to also filter by other attributes.
+func (c *Client) GetRecordsByDomainFiltered(domain string, types ...string) ([]Record, error) {
+	return c.GetRecordsByDomainFilteredContext(context.Background(), domain, types...)
+}
+
+// GetRecordsByDomainFilteredContext is like GetRecordsByDomainFiltered but uses ctx for the underlying request.
+func (c *Client) GetRecordsByDomainFilteredContext(ctx context.Context, domain string, types ...string) ([]Record, error) {
+	records, err := c.GetRecordsByDomainContext(ctx, domain)
+	if err != nil || len(types) == 0 {
+		return records, err
+	}
+
+	var matches []Record
+	for _, r := range records {
+		for _, t := range types {
+			if strings.EqualFold(r.Type, t) {
+				matches = append(matches, r)
+				break
+			}
+		}
+	}
+	return matches, nil
+}
+
+// RecordFilter selects records by several attributes at once, see
+// GetRecordsByFilter. Empty fields match any value; a record must match
+// all set fields.
+type RecordFilter struct {
+	// Name matches names as FindRecords does. It may also be a pattern
+	// as by path.Match, e.g. "_acme-challenge.*", matched against the
+	// name in lower case without trailing dot.
+	Name string
+	// Type matches the record type case-insensitively.
+	Type string
+	// Data matches record data as compared by Diff, so "2001:db8::1"
+	// matches "2001:0db8::0001".
+	Data string
+	// Tag and Annotation match exactly. Records without them never match.
+	Tag        string
+	Annotation string
+}
+
+// Match reports whether r, a record of domain, satisfies f.
+func (f RecordFilter) Match(domain string, r Record) bool {
+	if f.Name != "" && !f.matchName(domain, r.Name) {
+		return false
+	}
+	if f.Type != "" && !strings.EqualFold(r.Type, f.Type) {
+		return false
+	}
+	if f.Data != "" && normalizeData(r.Type, r.Data) != normalizeData(r.Type, f.Data) {
+		return false
+	}
+	if f.Tag != "" && (r.Tag == nil || *r.Tag != f.Tag) {
+		return false
+	}
+	if f.Annotation != "" && (r.Annotation == nil || *r.Annotation != f.Annotation) {
+		return false
+	}
+	return true
+}
+
+// matchName matches name against the Name field of f.
+func (f RecordFilter) matchName(domain, name string) bool {
+	if !strings.ContainsAny(f.Name, "*?[") {
+		return sameName(name, apexName(f.Name, domain))
+	}
+	pattern := strings.ToLower(strings.TrimSuffix(f.Name, "."))
+	ok, _ := path.Match(pattern, strings.ToLower(strings.TrimSuffix(name, ".")))
+	return ok
+}
+
+// GetRecordsByFilter retrieves the records of domain matching f, e.g.
+//
+//	client.GetRecordsByFilter("example.com", regfishapi.RecordFilter{Name: "_acme*", Type: "TXT", Tag: "ci"})
+//
+// for the TXT records tagged "ci" whose name starts with "_acme". The API
+// has no server-side filtering, so the whole zone is listed.
+func (c *Client) GetRecordsByFilter(domain string, f RecordFilter) ([]Record, error) {
+	return c.GetRecordsByFilterContext(context.Background(), domain, f)
+}
+
+// GetRecordsByFilterContext is like GetRecordsByFilter but uses ctx for the underlying request.
+func (c *Client) GetRecordsByFilterContext(ctx context.Context, domain string, f RecordFilter) ([]Record, error) {
+	records, err := c.GetRecordsByDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	var matches []Record
+	for _, r := range records {
+		if f.Match(domain, r) {
+			matches = append(matches, r)
+		}
+	}
+	return matches, nil
+}
+
+// GetRecordsByDomainGrouped retrieves the records of domain grouped by
+// type, as by GroupByType.
+func (c *Client) GetRecordsByDomainGrouped(domain string) (map[string][]Record, error) {
+	return c.GetRecordsByDomainGroupedContext(context.Background(), domain)
+}
+
+// GetRecordsByDomainGroupedContext is like GetRecordsByDomainGrouped but uses ctx for the underlying request.
+func (c *Client) GetRecordsByDomainGroupedContext(ctx context.Context, domain string) (map[string][]Record, error) {
+	records, err := c.GetRecordsByDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	return GroupByType(records), nil
+}
+
+// GroupByType returns records keyed by their type in upper case, e.g.
+// "MX". Each group keeps the order of records.
+func GroupByType(records []Record) map[string][]Record {
+	groups := make(map[string][]Record)
+	for _, r := range records {
+		t := string(RecordType(r.Type).Canonical())
+		groups[t] = append(groups[t], r)
+	}
+	return groups
+}
+
+// FindRecordsByData returns the records of every domain of the account
+// whose data equals data, e.g. all records pointing at an IP address that
+// is about to change. Each record has its Zone set. Data is compared as by
+// Diff, so "2001:db8::1" matches "2001:0db8::0001" and host names match
+// regardless of case and trailing dot. As with ExportAll, if some domains
+// fail the matches from the others are returned with the joined errors.
+func (c *Client) FindRecordsByData(data string) ([]Record, error) {
+	return c.FindRecordsByDataContext(context.Background(), data)
+}
+
+// FindRecordsByDataContext is like FindRecordsByData but uses ctx for the underlying requests.
+func (c *Client) FindRecordsByDataContext(ctx context.Context, data string) ([]Record, error) {
+	all, err := c.ExportAllContext(ctx)
+	var matches []Record
+	for _, r := range all {
+		if normalizeData(r.Type, r.Data) == normalizeData(r.Type, data) {
+			matches = append(matches, r)
+		}
+	}
+	return matches, err
+}
+
+// FindDuplicates returns the records of domain that duplicate another
+// record of domain, having the same name, type and data as compared by
+// Diff. Of every set of duplicates, the first record as listed by
+// GetRecordsByDomain is considered the original and left out of the
+// result, so deleting the result leaves one record per set, see
+// DeleteDuplicates.
+func (c *Client) FindDuplicates(domain string) ([]Record, error) {
+	return c.FindDuplicatesContext(context.Background(), domain)
+}
+
+// FindDuplicatesContext is like FindDuplicates but uses ctx for the underlying request.
+func (c *Client) FindDuplicatesContext(ctx context.Context, domain string) ([]Record, error) {
+	records, err := c.GetRecordsByDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(records))
+	var duplicates []Record
+	for _, r := range records {
+		key := recordKey(r)
+		if seen[key] {
+			duplicates = append(duplicates, r)
+			continue
+		}
+		seen[key] = true
+	}
+	return duplicates, nil
+}
+
+// filterRecords returns the records matching name and recordType, where
+// empty arguments match anything.
+func filterRecords(records []Record, name, recordType string) []Record {
+	var matches []Record
+	for _, r := range records {
+		if name != "" && !sameName(r.Name, name) {
+			continue
+		}
+		if recordType != "" && !strings.EqualFold(r.Type, recordType) {
+			continue
+		}
+		matches = append(matches, r)
+	}
+	return matches
+}
+
+// sameName reports whether two DNS names are equal, ignoring case and a
+// trailing dot. Unicode labels are compared in their punycode form, so
+// "www.müller.de" equals "www.xn--mller-kva.de.".
+func sameName(a, b string) bool {
+	return strings.EqualFold(comparableName(a), comparableName(b))
+}
+
+// comparableName returns name in punycode without trailing dot. Names that
+// can't be converted are returned as given.
+func comparableName(name string) string {
+	if ascii, err := ToASCII(name); err == nil {
+		name = ascii
+	}
+	return strings.TrimSuffix(name, ".")
+}
+
+// CountRecords returns the number of records of domain. The API has no
+// count endpoint, so the zone is still listed, but the records are counted
+// without being decoded.
+func (c *Client) CountRecords(domain string) (int, error) {
+	return c.CountRecordsContext(context.Background(), domain)
+}
+
+// CountRecordsContext is like CountRecords but uses ctx for the underlying request.
+func (c *Client) CountRecordsContext(ctx context.Context, domain string) (int, error) {
+	asciiDomain, err := ToASCII(domain)
+	if err != nil {
+		return 0, fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+
+	count := 0
+	err = c.getPages(ctx, fmt.Sprintf("/dns/%s/rr", asciiDomain), func(raw json.RawMessage) error {
+		var page []json.RawMessage
+		if err := c.unmarshal(raw, &page); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		count += len(page)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,217 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testZone = `{"response":[
+	{"id":1,"name":"example.com.","type":"NS","data":"ns1.regfish.de."},
+	{"id":2,"name":"www.example.com.","type":"A","data":"192.0.2.1"},
+	{"id":3,"name":"www.example.com.","type":"AAAA","data":"2001:db8::1"},
+	{"id":4,"name":"mail.example.com.","type":"A","data":"192.0.2.2"}
+]}`
+
+func zoneHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}
+}
+
+func TestFindRecords(t *testing.T) {
+	client := newTestClient(t, zoneHandler(testZone))
+
+	recs, err := client.FindRecords("example.com", "WWW.example.com", "a")
+	assert.NoError(t, err)
+	if assert.Len(t, recs, 1) {
+		assert.Equal(t, 2, recs[0].ID)
+	}
+
+	recs, err = client.FindRecords("example.com", "www.example.com.", "")
+	assert.NoError(t, err)
+	assert.Len(t, recs, 2)
+
+	recs, err = client.FindRecords("example.com", "", "A")
+	assert.NoError(t, err)
+	assert.Len(t, recs, 2)
+
+	recs, err = client.FindRecords("example.com", "missing.example.com", "")
+	assert.NoError(t, err)
+	assert.Empty(t, recs)
+}
+
+func TestGetRecordsByDomainFiltered(t *testing.T) {
+	client := newTestClient(t, zoneHandler(testZone))
+
+	recs, err := client.GetRecordsByDomainFiltered("example.com", "ns")
+	assert.NoError(t, err)
+	if assert.Len(t, recs, 1) {
+		assert.Equal(t, "ns1.regfish.de.", recs[0].Data)
+	}
+
+	recs, err = client.GetRecordsByDomainFiltered("example.com", "A", "AAAA")
+	assert.NoError(t, err)
+	assert.Len(t, recs, 3)
+
+	recs, err = client.GetRecordsByDomainFiltered("example.com")
+	assert.NoError(t, err)
+	assert.Len(t, recs, 4)
+}
+
+func TestGetRecordsByDomainZone(t *testing.T) {
+	client := newTestClient(t, zoneHandler(testZone))
+
+	recs, err := client.GetRecordsByDomain("example.com")
+	assert.NoError(t, err)
+	for _, r := range recs {
+		assert.Equal(t, "example.com", r.Zone)
+	}
+
+	data, err := json.Marshal(recs[0])
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "zone")
+}
+
+func TestCountRecords(t *testing.T) {
+	client := newTestClient(t, zoneHandler(testZone))
+
+	n, err := client.CountRecords("example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	client = newTestClient(t, zoneHandler(`{"response":[]}`))
+	n, err = client.CountRecords("example.com")
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestGetRecordByName(t *testing.T) {
+	client := newTestClient(t, zoneHandler(testZone))
+
+	rec, err := client.GetRecordByName("example.com", "www.example.com", "AAAA")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rec.ID)
+
+	_, err = client.GetRecordByName("example.com", "ftp.example.com", "A")
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client.GetRecordByName("example.com", "www.example.com", "")
+	assert.ErrorIs(t, err, ErrMultipleRecords)
+	assert.ErrorContains(t, err, "2 found")
+}
+
+const annotatedZone = `{"response":[
+	{"id":1,"name":"www.example.com.","type":"A","data":"192.0.2.1","annotation":"team-web","tag":"ci"},
+	{"id":2,"name":"api.example.com.","type":"A","data":"192.0.2.2","annotation":"team-api"},
+	{"id":3,"name":"cdn.example.com.","type":"CNAME","data":"www.example.com.","annotation":"team-web","tag":"ci"},
+	{"id":4,"name":"mail.example.com.","type":"A","data":"192.0.2.4"}
+]}`
+
+func TestGetRecordsByAnnotation(t *testing.T) {
+	client := newTestClient(t, zoneHandler(annotatedZone))
+
+	recs, err := client.GetRecordsByAnnotation("example.com", "team-web")
+	assert.NoError(t, err)
+	if assert.Len(t, recs, 2) {
+		assert.Equal(t, 1, recs[0].ID)
+		assert.Equal(t, 3, recs[1].ID)
+	}
+
+	recs, err = client.GetRecordsByAnnotation("example.com", "")
+	assert.NoError(t, err)
+	assert.Empty(t, recs)
+}
+
+func TestGetRecordsByTag(t *testing.T) {
+	client := newTestClient(t, zoneHandler(annotatedZone))
+
+	recs, err := client.GetRecordsByTag("example.com", "ci")
+	assert.NoError(t, err)
+	assert.Len(t, recs, 2)
+
+	recs, err = client.GetRecordsByTag("example.com", "none")
+	assert.NoError(t, err)
+	assert.Empty(t, recs)
+}
+
+func TestGetRecordsByDomainGrouped(t *testing.T) {
+	client := newTestClient(t, zoneHandler(testZone))
+
+	groups, err := client.GetRecordsByDomainGrouped("example.com")
+	assert.NoError(t, err)
+	assert.Len(t, groups, 3)
+	assert.Len(t, groups["NS"], 1)
+	if assert.Len(t, groups["A"], 2) {
+		assert.Equal(t, 2, groups["A"][0].ID)
+		assert.Equal(t, 4, groups["A"][1].ID)
+	}
+	assert.Len(t, groups["AAAA"], 1)
+
+	groups = GroupByType([]Record{{Type: "txt"}, {Type: "TXT"}})
+	assert.Len(t, groups["TXT"], 2)
+}
+
+func TestFindRecordsByData(t *testing.T) {
+	client := newTestClient(t, exportHandler)
+
+	recs, err := client.FindRecordsByData(" 192.0.2.1")
+	assert.ErrorContains(t, err, "export broken.net")
+	if assert.Len(t, recs, 1) {
+		assert.Equal(t, 1, recs[0].ID)
+		assert.Equal(t, "example.com", recs[0].Zone)
+	}
+
+	recs, _ = client.FindRecordsByData("MAIL.example.org")
+	if assert.Len(t, recs, 1) {
+		assert.Equal(t, "example.org", recs[0].Zone)
+	}
+
+	recs, _ = client.FindRecordsByData("198.51.100.1")
+	assert.Empty(t, recs)
+}
+
+func TestGetRecordsByFilter(t *testing.T) {
+	client := newTestClient(t, zoneHandler(`{"response":[
+		{"id":1,"name":"_acme-challenge.www.example.com.","type":"TXT","data":"\"token\"","tag":"ci"},
+		{"id":2,"name":"_acme-challenge.api.example.com.","type":"TXT","data":"\"token\""},
+		{"id":3,"name":"www.example.com.","type":"TXT","data":"\"v=spf1 -all\"","tag":"ci"},
+		{"id":4,"name":"example.com.","type":"A","data":"192.0.2.1","annotation":"apex"},
+		{"id":5,"name":"www.example.com.","type":"AAAA","data":"2001:db8::1"}
+	]}`))
+
+	tests := []struct {
+		filter RecordFilter
+		want   []int
+	}{
+		{RecordFilter{}, []int{1, 2, 3, 4, 5}},
+		{RecordFilter{Name: "_acme*", Type: "txt", Tag: "ci"}, []int{1}},
+		{RecordFilter{Name: "_ACME-challenge.*.example.com."}, []int{1, 2}},
+		{RecordFilter{Name: "www.example.com"}, []int{3, 5}},
+		{RecordFilter{Name: "@", Annotation: "apex"}, []int{4}},
+		{RecordFilter{Data: "2001:0db8::0001"}, []int{5}},
+		{RecordFilter{Type: "TXT", Data: `"token"`}, []int{1, 2}},
+		{RecordFilter{Tag: "none"}, nil},
+	}
+	for _, tt := range tests {
+		recs, err := client.GetRecordsByFilter("example.com", tt.filter)
+		assert.NoError(t, err)
+		var ids []int
+		for _, r := range recs {
+			ids = append(ids, r.ID)
+		}
+		assert.Equal(t, tt.want, ids, "%+v", tt.filter)
+	}
+}
+
+func TestGetRecordByNameApex(t *testing.T) {
+	client := newTestClient(t, zoneHandler(`{"response":[{"id":2,"name":"www.example.com.","type":"A","data":"192.0.2.1"}]}`))
+
+	for _, name := range []string{"", "@"} {
+		_, err := client.GetRecordByName("example.com", name, "A")
+		assert.ErrorIs(t, err, ErrRecordNotFound, "%q", name)
+	}
+}
@@ -0,0 +1,33 @@
+package regfishapi
+
+import (
+	"context"
+	"net/http"
+)
+
+type headerKey struct{}
+
+// ContextWithHeader returns a copy of ctx that makes the Client send the
+// header key with value on requests made with it, in addition to those
+// configured on the Client. Use it with the Context variants of the
+// Client's methods to attach per-call headers such as a trace ID:
+//
+//	ctx = regfishapi.ContextWithHeader(ctx, "X-Correlation-Id", id)
+//	rec, err := client.GetRecordContext(ctx, rrid)
+//
+// A per-call header replaces a Client header of the same name.
+func ContextWithHeader(ctx context.Context, key, value string) context.Context {
+	h := headersFromContext(ctx).Clone()
+	if h == nil {
+		h = make(http.Header)
+	}
+	h.Set(key, value)
+	return context.WithValue(ctx, headerKey{}, h)
+}
+
+// headersFromContext returns the headers attached to ctx by
+// ContextWithHeader. The result must not be modified.
+func headersFromContext(ctx context.Context) http.Header {
+	h, _ := ctx.Value(headerKey{}).(http.Header)
+	return h
+}
@@ -0,0 +1,34 @@
+package regfishapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaders(t *testing.T) {
+	var got http.Header
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"response":{}}`))
+	}, WithHeader("x-correlation-id", "client"), WithHeader("X-Team", "dns"))
+
+	_, err := client.GetRecord(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "client", got.Get("X-Correlation-Id"))
+	assert.Equal(t, "dns", got.Get("X-Team"))
+
+	ctx := ContextWithHeader(context.Background(), "X-Correlation-Id", "call")
+	ctx = ContextWithHeader(ctx, "X-Trace", "abc")
+	_, err = client.GetRecordContext(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"call"}, got.Values("X-Correlation-Id"))
+	assert.Equal(t, "abc", got.Get("X-Trace"))
+	assert.Equal(t, "dns", got.Get("X-Team"))
+
+	_, err = client.GetRecord(1)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Get("X-Trace"))
+}
@@ -0,0 +1,57 @@
+package regfishapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type hooksKey struct{}
+
+// callHooks holds the hooks attached to a context.
+type callHooks struct {
+	onRequest  []func(*http.Request)
+	onResponse []func(*http.Response, time.Duration)
+}
+
+// ContextWithOnRequest returns a copy of ctx that makes the Client call fn
+// before every HTTP request made with it, in addition to Client.OnRequest.
+func ContextWithOnRequest(ctx context.Context, fn func(req *http.Request)) context.Context {
+	h := hooksFromContext(ctx)
+	h.onRequest = append(h.onRequest[:len(h.onRequest):len(h.onRequest)], fn)
+	return context.WithValue(ctx, hooksKey{}, h)
+}
+
+// ContextWithOnResponse returns a copy of ctx that makes the Client call
+// fn after every HTTP round trip made with it, in addition to
+// Client.OnResponse.
+func ContextWithOnResponse(ctx context.Context, fn func(resp *http.Response, elapsed time.Duration)) context.Context {
+	h := hooksFromContext(ctx)
+	h.onResponse = append(h.onResponse[:len(h.onResponse):len(h.onResponse)], fn)
+	return context.WithValue(ctx, hooksKey{}, h)
+}
+
+func hooksFromContext(ctx context.Context) callHooks {
+	h, _ := ctx.Value(hooksKey{}).(callHooks)
+	return h
+}
+
+// onRequest runs the request hooks of the Client and of ctx.
+func (c *Client) onRequest(ctx context.Context, req *http.Request) {
+	if c.OnRequest != nil {
+		c.OnRequest(req)
+	}
+	for _, fn := range hooksFromContext(ctx).onRequest {
+		fn(req)
+	}
+}
+
+// onResponse runs the response hooks of the Client and of ctx.
+func (c *Client) onResponse(ctx context.Context, resp *http.Response, elapsed time.Duration) {
+	if c.OnResponse != nil {
+		c.OnResponse(resp, elapsed)
+	}
+	for _, fn := range hooksFromContext(ctx).onResponse {
+		fn(resp, elapsed)
+	}
+}
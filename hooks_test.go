@@ -0,0 +1,57 @@
+package regfishapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHooks(t *testing.T) {
+	var requests, statuses []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/dns/rr/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"response":{"id":1}}`))
+	},
+		WithOnRequest(func(req *http.Request) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+		}),
+		WithOnResponse(func(resp *http.Response, elapsed time.Duration) {
+			statuses = append(statuses, resp.Status)
+			assert.Positive(t, elapsed)
+		}),
+	)
+
+	_, err := client.GetRecord(1)
+	assert.NoError(t, err)
+	_, err = client.GetRecord(2)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"GET /dns/rr/1", "GET /dns/rr/2"}, requests)
+	assert.Equal(t, []string{"200 OK", "404 Not Found"}, statuses)
+
+	var perCall []int
+	ctx := ContextWithOnResponse(context.Background(), func(resp *http.Response, elapsed time.Duration) {
+		perCall = append(perCall, resp.StatusCode)
+	})
+	_, err = client.GetRecordContext(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{200}, perCall)
+	assert.Len(t, statuses, 3)
+}
+
+func TestOnResponseNetworkError(t *testing.T) {
+	called := false
+	client := NewClient("key", WithBaseURL("http://127.0.0.1:1"), WithOnResponse(func(resp *http.Response, elapsed time.Duration) {
+		called = true
+		assert.Nil(t, resp)
+	}))
+
+	_, err := client.GetRecord(1)
+	assert.Error(t, err)
+	assert.True(t, called)
+}
@@ -0,0 +1,58 @@
+package regfishapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a
+// POST request. A server honouring it processes repeated requests with the
+// same key only once, so a retried create doesn't add a duplicate record.
+// Whether the Regfish API deduplicates requests this way isn't documented;
+// the header is harmless if it is ignored.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// ContextWithIdempotencyKey returns a copy of ctx that makes POST requests
+// made with it, such as CreateRecordContext, carry key in the
+// IdempotencyKeyHeader. Use a new key for every logical operation.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return ContextWithHeader(ctx, IdempotencyKeyHeader, key)
+}
+
+// idempotencyHeaders returns headers with an idempotency key added if
+// method is POST, the request may be retried and the caller didn't supply
+// a key. The key is shared by all attempts of the request.
+func (c *Client) idempotencyHeaders(ctx context.Context, method string, headers map[string]string) map[string]string {
+	if method != http.MethodPost || c.MaxRetries == 0 || !c.RetryNonIdempotent {
+		return headers
+	}
+	if headersFromContext(ctx).Get(IdempotencyKeyHeader) != "" || c.Header.Get(IdempotencyKeyHeader) != "" {
+		return headers
+	}
+	for k := range headers {
+		if http.CanonicalHeaderKey(k) == IdempotencyKeyHeader {
+			return headers
+		}
+	}
+
+	out := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		out[k] = v
+	}
+	out[IdempotencyKeyHeader] = newIdempotencyKey()
+	return out
+}
+
+// newIdempotencyKey returns a random key in the form of a UUID version 4.
+func newIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("regfishapi: failed to generate idempotency key: " + err.Error())
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	s := hex.EncodeToString(b[:])
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
@@ -0,0 +1,52 @@
+package regfishapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKeyGenerated(t *testing.T) {
+	var keys []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"response":{"id":1}}`))
+	}, WithRetry(1, 0))
+	client.RetryBackoff = noBackoff
+	client.RetryNonIdempotent = true
+
+	_, err := client.CreateRecord(Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	assert.NoError(t, err)
+	if assert.Len(t, keys, 2) {
+		assert.Len(t, keys[0], 36)
+		assert.Equal(t, keys[0], keys[1], "retries must reuse the key")
+	}
+
+	keys = nil
+	_, err = client.CreateRecord(Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func TestIdempotencyKeyExplicit(t *testing.T) {
+	var key string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get(IdempotencyKeyHeader)
+		w.Write([]byte(`{"response":{"id":1}}`))
+	})
+
+	_, err := client.CreateRecord(Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	assert.NoError(t, err)
+	assert.Empty(t, key, "no key without POST retries")
+
+	ctx := ContextWithIdempotencyKey(context.Background(), "create-www")
+	_, err = client.CreateRecordContext(ctx, Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "create-www", key)
+}
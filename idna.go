@@ -0,0 +1,45 @@
+package regfishapi
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// idnaProfile converts between Unicode and punycode names. It follows the
+// lookup rules of UTS #46 but, unlike idna.Lookup, accepts underscores so
+// service labels such as "_acme-challenge" pass through unchanged.
+var idnaProfile = idna.New(
+	idna.MapForLookup(),
+	idna.StrictDomainName(false),
+	idna.Transitional(false),
+)
+
+// ToASCII converts a domain or record name containing Unicode labels, such
+// as "müller.de", to its punycode form ("xn--mller-kva.de"). Names that are
+// already ASCII are returned unchanged.
+func ToASCII(name string) (string, error) {
+	if isASCII(name) {
+		return name, nil
+	}
+	return idnaProfile.ToASCII(name)
+}
+
+// ToUnicode converts punycode labels in name back to Unicode for display.
+// Names without punycode labels are returned unchanged.
+func ToUnicode(name string) (string, error) {
+	if !strings.Contains(strings.ToLower(name), "xn--") {
+		return name, nil
+	}
+	return idnaProfile.ToUnicode(name)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package regfishapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToASCII(t *testing.T) {
+	for in, want := range map[string]string{
+		"müller.de":                  "xn--mller-kva.de",
+		"www.müller.de.":             "www.xn--mller-kva.de.",
+		"_acme-challenge.müller.de.": "_acme-challenge.xn--mller-kva.de.",
+		"Example.COM.":               "Example.COM.",
+	} {
+		got, err := ToASCII(in)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestToUnicode(t *testing.T) {
+	got, err := ToUnicode("www.xn--mller-kva.de.")
+	assert.NoError(t, err)
+	assert.Equal(t, "www.müller.de.", got)
+
+	got, err = ToUnicode("www.example.com.")
+	assert.NoError(t, err)
+	assert.Equal(t, "www.example.com.", got)
+}
+
+func TestGetRecordsByDomainIDN(t *testing.T) {
+	var path string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"response":[{"id":1,"name":"www.xn--mller-kva.de.","type":"A","data":"192.0.2.1"}]}`))
+	}, WithUnicodeNames())
+
+	recs, err := client.GetRecordsByDomain("müller.de")
+	assert.NoError(t, err)
+	assert.Equal(t, "/dns/xn--mller-kva.de/rr", path)
+	if assert.Len(t, recs, 1) {
+		assert.Equal(t, "www.müller.de.", recs[0].Name)
+	}
+}
+
+func TestUpsertRecordIDN(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithUnicodeNames()}} {
+		zone := newFakeZone(Record{ID: 1, Name: "www.xn--mller-kva.de.", Type: "A", Data: "192.0.2.1"})
+		client := newTestClient(t, zone.ServeHTTP, opts...)
+
+		_, err := client.UpsertRecord("müller.de", NewARecord("www.müller.de.", "192.0.2.2", 0))
+		assert.NoError(t, err)
+		_, err = client.UpsertRecord("müller.de", NewARecord("www.xn--mller-kva.de.", "192.0.2.3", 0))
+		assert.NoError(t, err)
+		if assert.Len(t, zone.records, 1, "updated, not created") {
+			assert.Equal(t, "192.0.2.3", zone.records[1].Data)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package regfishapi
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ImportResult reports the outcome of one row of ImportRecordsCSV.
+type ImportResult struct {
+	// Line is the line of the row in the CSV input.
+	Line int
+	// Record is the created record, or the parsed one if creating it failed.
+	Record Record
+	// Err is nil if the record was created.
+	Err error
+}
+
+// ImportRecordsCSV parses CSV rows with the columns zone, name, type, data,
+// ttl and priority and creates the records concurrently. Names are resolved
+// relative to the zone as by AbsoluteName, so "www" and "@" work as they do
+// in a zonefile. ttl and priority may be empty.
+//
+// If the first row is a header starting with "zone", columns are matched by
+// name instead, which also picks up the flags, tag and annotation columns
+// written by WriteRecordsCSV.
+//
+// The result has one entry per data row. Rows that can't be parsed are not
+// sent. If any row failed, the returned error joins their errors; it is
+// also returned alone if the input isn't valid CSV.
+func (c *Client) ImportRecordsCSV(r io.Reader) ([]ImportResult, error) {
+	return c.ImportRecordsCSVContext(context.Background(), r)
+}
+
+// ImportRecordsCSVContext is like ImportRecordsCSV but uses ctx for the underlying requests.
+func (c *Client) ImportRecordsCSVContext(ctx context.Context, r io.Reader) ([]ImportResult, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	columns := map[string]int{"zone": 0, "name": 1, "type": 2, "data": 3, "ttl": 4, "priority": 5}
+	var results []ImportResult
+	for first := true; ; first = false {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if first && strings.EqualFold(strings.TrimSpace(row[0]), "zone") {
+			columns = make(map[string]int)
+			for i, name := range row {
+				columns[strings.ToLower(strings.TrimSpace(name))] = i
+			}
+			continue
+		}
+		rec, err := parseCSVRecord(row, columns)
+		results = append(results, ImportResult{Line: line, Record: rec, Err: err})
+	}
+
+	var toCreate []Record
+	var indices []int
+	for i, res := range results {
+		if res.Err == nil {
+			toCreate = append(toCreate, res.Record)
+			indices = append(indices, i)
+		}
+	}
+	created, err := c.CreateRecordsContext(ctx, toCreate)
+	var batchErr *BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return results, err
+	}
+	for j, i := range indices {
+		if created[j] == (Record{}) {
+			// Not started because ctx is done.
+			results[i].Err = ctx.Err()
+			continue
+		}
+		created[j].Zone = results[i].Record.Zone
+		results[i].Record = created[j]
+	}
+	if batchErr != nil {
+		for _, e := range batchErr.Errors {
+			i := indices[e.Index]
+			results[i].Err = e.Err
+		}
+	}
+
+	var errs []error
+	for _, res := range results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", res.Line, res.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// parseCSVRecord builds a record from row, whose fields are located by
+// columns.
+func parseCSVRecord(row []string, columns map[string]int) (Record, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	intField := func(name string) (*int, error) {
+		s := field(name)
+		if s == "" {
+			return nil, nil
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", name, s)
+		}
+		return &v, nil
+	}
+
+	zone := field("zone")
+	rec := Record{
+		Name: AbsoluteName(field("name"), zone),
+		Type: strings.ToUpper(field("type")),
+		Data: field("data"),
+		Zone: zone,
+	}
+	if zone == "" || rec.Type == "" || rec.Data == "" {
+		return rec, errors.New("zone, type and data are required")
+	}
+	var err error
+	if rec.TTL, err = intField("ttl"); err != nil {
+		return rec, err
+	}
+	if rec.Priority, err = intField("priority"); err != nil {
+		return rec, err
+	}
+	if rec.Flags, err = intField("flags"); err != nil {
+		return rec, err
+	}
+	if tag := field("tag"); tag != "" {
+		rec.Tag = &tag
+	}
+	if annotation := field("annotation"); annotation != "" {
+		rec.Annotation = &annotation
+	}
+	return rec, nil
+}
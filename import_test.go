@@ -0,0 +1,88 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportRecordsCSV(t *testing.T) {
+	var mu sync.Mutex
+	var sent []Record
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var rec Record
+		json.NewDecoder(r.Body).Decode(&rec)
+		if rec.Data == "192.0.2.99" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		sent = append(sent, rec)
+		rec.ID = len(sent)
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]Record{"response": rec})
+	})
+
+	input := "example.com,www,A,192.0.2.1,300,\n" +
+		"example.com,@,MX,mail.example.com.,,10\n" +
+		"example.com,bad,A,192.0.2.1,soon,\n" +
+		"example.com,fail,A,192.0.2.99,,\n"
+	results, err := client.ImportRecordsCSV(strings.NewReader(input))
+	assert.ErrorContains(t, err, `line 3: invalid ttl "soon"`)
+	assert.ErrorContains(t, err, "line 4: POST /dns/rr: request failed with status code 400")
+	if !assert.Len(t, results, 4) {
+		return
+	}
+
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "www.example.com.", results[0].Record.Name)
+	assert.Equal(t, 300, *results[0].Record.TTL)
+	assert.Equal(t, "example.com", results[0].Record.Zone)
+	assert.NotZero(t, results[0].Record.ID)
+
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, "example.com.", results[1].Record.Name)
+	assert.Equal(t, 10, *results[1].Record.Priority)
+	assert.Nil(t, results[1].Record.TTL)
+
+	assert.Equal(t, 3, results[2].Line)
+	assert.Error(t, results[2].Err)
+	assert.Error(t, results[3].Err)
+	assert.Equal(t, "fail.example.com.", results[3].Record.Name)
+	assert.Len(t, sent, 2)
+}
+
+func TestImportRecordsCSVHeader(t *testing.T) {
+	var sent Record
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"response":{"id":1}}`))
+	})
+
+	var buf strings.Builder
+	WriteRecordsCSV(&buf, []Record{{
+		ID: 9, Zone: "example.com", Name: "example.com.", Type: "CAA", Data: "letsencrypt.org",
+		Flags: IntPtr(0), Tag: StringPtr("issue"), Annotation: StringPtr("certs"),
+	}})
+	results, err := client.ImportRecordsCSV(strings.NewReader(buf.String()))
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, 2, results[0].Line)
+	assert.Equal(t, "issue", *sent.Tag)
+	assert.Equal(t, 0, *sent.Flags)
+	assert.Equal(t, "certs", *sent.Annotation)
+	assert.Zero(t, sent.ID)
+}
+
+func TestImportRecordsCSVInvalid(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("nothing should be sent")
+	})
+
+	_, err := client.ImportRecordsCSV(strings.NewReader("example.com,\"www,A\n"))
+	assert.Error(t, err)
+}
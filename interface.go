@@ -0,0 +1,33 @@
+package regfishapi
+
+import (
+	"context"
+)
+
+// DNSClient is the set of record and domain operations provided by Client.
+// Code that depends on DNSClient instead of *Client can be tested with a
+// fake such as regfishmock.Client.
+type DNSClient interface {
+	GetRecord(rrid int) (Record, error)
+	GetRecordContext(ctx context.Context, rrid int) (Record, error)
+	CreateRecord(record Record) (Record, error)
+	CreateRecordContext(ctx context.Context, record Record) (Record, error)
+	UpdateRecord(record Record) (Record, error)
+	UpdateRecordContext(ctx context.Context, record Record) (Record, error)
+	UpdateRecordById(rrid int, record Record) (Record, error)
+	UpdateRecordByIdContext(ctx context.Context, rrid int, record Record) (Record, error)
+	DeleteRecord(rrid int) (Record, error)
+	DeleteRecordContext(ctx context.Context, rrid int) (Record, error)
+	GetRecordsByDomain(domain string) ([]Record, error)
+	GetRecordsByDomainContext(ctx context.Context, domain string) ([]Record, error)
+	FindRecords(domain, name, recordType string) ([]Record, error)
+	FindRecordsContext(ctx context.Context, domain, name, recordType string) ([]Record, error)
+	UpsertRecord(domain string, record Record) (Record, error)
+	UpsertRecordContext(ctx context.Context, domain string, record Record) (Record, error)
+	ListDomains() ([]Domain, error)
+	ListDomainsContext(ctx context.Context) ([]Domain, error)
+	Ping() error
+	PingContext(ctx context.Context) error
+}
+
+var _ DNSClient = (*Client)(nil)
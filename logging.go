@@ -0,0 +1,68 @@
+package regfishapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Logger receives debug output from the Client. *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// redacted replaces secret header values in debug output.
+const redacted = "[REDACTED]"
+
+// logRequest writes req and its body to the configured Logger.
+func (c *Client) logRequest(req *http.Request, body []byte) {
+	if c.Logger == nil {
+		return
+	}
+	c.logf("--> %s %s\n%s%s", req.Method, req.URL, formatHeaders(req.Header), body)
+}
+
+// logResponse writes resp and its body to the configured Logger.
+func (c *Client) logResponse(req *http.Request, resp *http.Response, body []byte, elapsed time.Duration) {
+	if c.Logger == nil {
+		return
+	}
+	c.logf("<-- %s %s %s (%s)\n%s%s", req.Method, req.URL, resp.Status, elapsed.Round(time.Millisecond), formatHeaders(resp.Header), body)
+}
+
+// logf formats a message and writes it to the configured Logger with the
+// API key redacted, in case the server echoes it in a body.
+func (c *Client) logf(format string, v ...interface{}) {
+	c.Logger.Printf("%s", c.redact(fmt.Sprintf(format, v...)))
+}
+
+// formatHeaders renders h one header per line in a stable order, with
+// credentials redacted.
+func formatHeaders(h http.Header) string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		v := strings.Join(h[k], ", ")
+		if isSecretHeader(k) {
+			v = redacted
+		}
+		b.WriteString(k + ": " + v + "\n")
+	}
+	return b.String()
+}
+
+// isSecretHeader reports whether the header named k carries credentials.
+func isSecretHeader(k string) bool {
+	switch http.CanonicalHeaderKey(k) {
+	case "X-Api-Key", "Authorization", "Proxy-Authorization":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package regfishapi
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDebug(t *testing.T) {
+	var buf bytes.Buffer
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"invalid data"}`))
+	}, WithDebug(&buf))
+
+	_, err := client.CreateRecord(NewARecord("www.example.com.", "192.0.2.1", 300))
+	assert.Error(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "--> POST "+client.BaseURL+"/dns/rr")
+	assert.Contains(t, out, `"data":"192.0.2.1"`)
+	assert.Contains(t, out, "X-Api-Key: [REDACTED]")
+	assert.Contains(t, out, "<-- POST "+client.BaseURL+"/dns/rr 400 Bad Request")
+	assert.Contains(t, out, `{"message":"invalid data"}`)
+	assert.NotContains(t, out, "test-key")
+}
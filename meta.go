@@ -0,0 +1,45 @@
+package regfishapi
+
+import (
+	"net/http"
+	"time"
+)
+
+// ResponseMeta describes an HTTP response received from the API.
+type ResponseMeta struct {
+	StatusCode int
+	// RequestID is the X-Request-Id header, which Regfish support can use
+	// to locate the request.
+	RequestID string
+	Header    http.Header
+	Received  time.Time
+}
+
+// LastResponse returns metadata of the most recent response received by
+// the Client, successful or not. When the Client is shared between
+// goroutines this may belong to another goroutine's request; use the
+// RequestID field of an *APIError to identify a specific failed call.
+func (c *Client) LastResponse() ResponseMeta {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastResponse
+}
+
+// observeResponse records the metadata and rate-limit state of resp.
+func (c *Client) observeResponse(resp *http.Response) {
+	now := time.Now()
+	meta := ResponseMeta{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Header:     resp.Header.Clone(),
+		Received:   now,
+	}
+	rl, hasRateLimit := parseRateLimit(resp.Header, now)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastResponse = meta
+	if hasRateLimit {
+		c.rateLimit = rl
+	}
+}
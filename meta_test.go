@@ -0,0 +1,35 @@
+package regfishapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastResponse(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-"+r.URL.Path[len("/dns/rr/"):])
+		if r.URL.Path == "/dns/rr/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"response":{"id":1}}`))
+	})
+
+	_, err := client.GetRecord(1)
+	assert.NoError(t, err)
+	meta := client.LastResponse()
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+	assert.Equal(t, "req-1", meta.RequestID)
+	assert.False(t, meta.Received.IsZero())
+
+	_, err = client.GetRecord(2)
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, "req-2", apiErr.RequestID)
+	}
+	assert.Equal(t, http.StatusNotFound, client.LastResponse().StatusCode)
+}
@@ -0,0 +1,76 @@
+package regfishapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// GetNameservers returns the name servers of the NS records at the apex of
+// domain.
+func (c *Client) GetNameservers(domain string) ([]string, error) {
+	return c.GetNameserversContext(context.Background(), domain)
+}
+
+// GetNameserversContext is like GetNameservers but uses ctx for the underlying request.
+func (c *Client) GetNameserversContext(ctx context.Context, domain string) ([]string, error) {
+	records, err := c.FindRecordsContext(ctx, domain, domain, "NS")
+	if err != nil {
+		return nil, err
+	}
+	nameservers := make([]string, len(records))
+	for i, r := range records {
+		nameservers[i] = r.Data
+	}
+	return nameservers, nil
+}
+
+// SetNameservers makes nameservers the NS record set of name in domain,
+// e.g. to delegate a subdomain, as by ReplaceRecordSet. name is resolved
+// relative to domain as by CreateRecordInZone. Every name server must be a
+// fully qualified host name, see ValidateNameserver; nothing is changed if
+// one isn't. Use ResolveNameservers beforehand to also check that they resolve.
+func (c *Client) SetNameservers(domain, name string, nameservers []string) (SyncResult, error) {
+	return c.SetNameserversContext(context.Background(), domain, name, nameservers)
+}
+
+// SetNameserversContext is like SetNameservers but uses ctx for the underlying requests.
+func (c *Client) SetNameserversContext(ctx context.Context, domain, name string, nameservers []string) (SyncResult, error) {
+	datas := make([]string, len(nameservers))
+	for i, ns := range nameservers {
+		if err := ValidateNameserver(ns); err != nil {
+			return SyncResult{}, err
+		}
+		datas[i] = fqdn(ns)
+	}
+	return c.ReplaceRecordSetContext(ctx, domain, AbsoluteName(apexName(name, domain), domain), "NS", datas, 0)
+}
+
+// ValidateNameserver checks that ns is a fully qualified host name such as
+// "ns1.example.net" with or without the trailing dot, and not an IP address
+// or a single label. It returns a *ValidationError for the data field.
+func ValidateNameserver(ns string) error {
+	host := strings.TrimSuffix(ns, ".")
+	if net.ParseIP(host) != nil {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("name server %q must be a host name, not an IP address", ns)}
+	}
+	if err := validateName(ns); err != nil || !strings.Contains(host, ".") {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("name server %q is not a fully qualified host name", ns)}
+	}
+	return nil
+}
+
+// ResolveNameservers checks that each of nameservers resolves to at least
+// one address using the system resolver, to catch delegations to hosts
+// that don't exist. The failures are joined into the returned error.
+func ResolveNameservers(ctx context.Context, nameservers []string) error {
+	var errs []error
+	for _, ns := range nameservers {
+		if _, err := net.DefaultResolver.LookupHost(ctx, ns); err != nil {
+			errs = append(errs, fmt.Errorf("name server %s: %w", ns, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,58 @@
+package regfishapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNameservers(t *testing.T) {
+	client := newTestClient(t, zoneHandler(`{"response":[
+		{"id":1,"name":"example.com.","type":"NS","data":"ns1.regfish.de."},
+		{"id":2,"name":"example.com.","type":"NS","data":"ns2.regfish.de."},
+		{"id":3,"name":"sub.example.com.","type":"NS","data":"ns.example.net."}
+	]}`))
+
+	nameservers, err := client.GetNameservers("example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns1.regfish.de.", "ns2.regfish.de."}, nameservers)
+}
+
+func TestSetNameservers(t *testing.T) {
+	zone := newFakeZone(
+		Record{ID: 1, Name: "example.com.", Type: "NS", Data: "ns1.regfish.de."},
+		Record{ID: 2, Name: "sub.example.com.", Type: "NS", Data: "ns1.example.net."},
+	)
+	client := newTestClient(t, zone.ServeHTTP)
+
+	result, err := client.SetNameservers("example.com", "sub", []string{"ns1.example.net", "ns2.example.net."})
+	assert.NoError(t, err)
+	if assert.Len(t, result.Created, 1) {
+		assert.Equal(t, "sub.example.com.", result.Created[0].Name)
+		assert.Equal(t, "ns2.example.net.", result.Created[0].Data)
+	}
+	assert.Empty(t, result.Deleted)
+	assert.Equal(t, []string{"ns1.example.net.", "ns2.example.net."}, zoneData(zone, "sub.example.com.", "NS"))
+
+	_, err = client.SetNameservers("example.com", "sub", []string{"ns3.example.net", "192.0.2.53"})
+	var verr *ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Len(t, zone.records, 3, "nothing must change")
+}
+
+func TestValidateNameserver(t *testing.T) {
+	assert.NoError(t, ValidateNameserver("ns1.example.net"))
+	assert.NoError(t, ValidateNameserver("ns1.example.net."))
+	assert.Error(t, ValidateNameserver("ns1"))
+	assert.Error(t, ValidateNameserver("192.0.2.53"))
+	assert.Error(t, ValidateNameserver("2001:db8::53"))
+	assert.Error(t, ValidateNameserver("ns1..example.net"))
+	assert.Error(t, ValidateNameserver(""))
+}
+
+func TestResolveNameservers(t *testing.T) {
+	assert.NoError(t, ResolveNameservers(context.Background(), []string{"localhost"}))
+	assert.ErrorContains(t, ResolveNameservers(context.Background(), []string{"localhost", "ns.invalid."}), "name server ns.invalid.")
+}
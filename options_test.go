@@ -0,0 +1,227 @@
+package regfishapi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientOptions(t *testing.T) {
+	hc := &http.Client{}
+	client := NewClient("key",
+		WithHTTPClient(hc),
+		WithBaseURL("https://staging.example.com/"),
+		WithTimeout(5*time.Second),
+		WithUserAgent("my-tool/1.0"),
+		WithRetry(3, time.Second),
+	)
+
+	assert.Same(t, hc, client.Client)
+	assert.Equal(t, "https://staging.example.com", client.BaseURL)
+	assert.Equal(t, 5*time.Second, client.RequestTimeout)
+	assert.Equal(t, "my-tool/1.0", client.UserAgent)
+	assert.Equal(t, 3, client.MaxRetries)
+	assert.NotNil(t, client.RetryBackoff)
+}
+
+func TestWithTimeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}, WithTimeout(50*time.Millisecond))
+
+	_, err := client.GetRecord(1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDefaultRequestTimeout(t *testing.T) {
+	assert.Equal(t, DefaultRequestTimeout, NewClient("key").RequestTimeout)
+	assert.Zero(t, NewClient("key", WithTimeout(0)).RequestTimeout)
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var ua string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ua = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"response":{}}`))
+	}, WithUserAgent("my-tool/1.0"))
+
+	_, err := client.GetRecord(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-tool/1.0", ua)
+}
+
+func TestDefaultUserAgent(t *testing.T) {
+	var ua string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ua = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"response":{}}`))
+	})
+
+	_, err := client.GetRecord(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "regfish-dnsapi-go/"+Version, ua)
+}
+
+func TestWithBaseURLValidation(t *testing.T) {
+	for _, u := range []string{
+		"https://api.regfish.de",
+		"https://staging.example.com/v2/",
+		"http://127.0.0.1:8080",
+		"http://localhost:8080",
+		"http://[::1]:8080",
+	} {
+		assert.NoError(t, validateBaseURL(u), u)
+	}
+	for _, u := range []string{
+		"api.regfish.de",
+		"http://api.regfish.de",
+		"https://",
+		"https://api.regfish.de?x=1",
+		"ftp://api.regfish.de",
+		"https://api regfish.de",
+	} {
+		assert.Error(t, validateBaseURL(u), u)
+	}
+
+	client := NewClient("key", WithBaseURL("htps://api.regfish.de"))
+	assert.Equal(t, DefaultBaseURL, client.BaseURL)
+	_, err := client.GetRecord(1)
+	assert.ErrorContains(t, err, "invalid base URL")
+
+	client = NewClient("key")
+	client.BaseURL = "http://api.regfish.de"
+	_, err = client.GetRecord(1)
+	assert.ErrorContains(t, err, "scheme must be https")
+}
+
+func TestRefuseInsecureRedirect(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dns/rr/1":
+			http.Redirect(w, r, "http://api.example.net/dns/rr/1", http.StatusFound)
+		case "/dns/rr/2":
+			http.Redirect(w, r, "/dns/rr/3", http.StatusFound)
+		default:
+			w.Write([]byte(`{"response":{"id":3}}`))
+		}
+	})
+
+	_, err := client.GetRecord(1)
+	assert.ErrorContains(t, err, "refusing redirect to http://api.example.net/dns/rr/1")
+
+	rec, err := client.GetRecord(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rec.ID)
+}
+
+func TestTLSOptions(t *testing.T) {
+	pool := x509.NewCertPool()
+	client := NewClient("key", WithMinTLSVersion(tls.VersionTLS13), WithRootCAs(pool))
+	tr, ok := client.Client.Transport.(*http.Transport)
+	if assert.True(t, ok) {
+		assert.Equal(t, uint16(tls.VersionTLS13), tr.TLSClientConfig.MinVersion)
+		assert.Same(t, pool, tr.TLSClientConfig.RootCAs)
+	}
+
+	cfg := &tls.Config{ServerName: "api.example.com"}
+	client = NewClient("key", WithTLSConfig(cfg), WithMinTLSVersion(tls.VersionTLS12))
+	tr = client.Client.Transport.(*http.Transport)
+	assert.Equal(t, "api.example.com", tr.TLSClientConfig.ServerName)
+	assert.Zero(t, cfg.MinVersion, "the caller's config must not change")
+
+	hc := &http.Client{}
+	client = NewClient("key", WithMinTLSVersion(tls.VersionTLS13), WithHTTPClient(hc))
+	assert.Same(t, hc, client.Client)
+	assert.Nil(t, hc.Transport)
+}
+
+func TestWithRootCAs(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":{"id":1}}`))
+	}))
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	_, err := NewClient("key", WithBaseURL(srv.URL)).GetRecord(1)
+	assert.Error(t, err, "untrusted certificate")
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	rec, err := NewClient("key", WithBaseURL(srv.URL), WithRootCAs(pool)).GetRecord(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rec.ID)
+}
+
+func TestWithProxy(t *testing.T) {
+	var auth, target string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Proxy-Authorization")
+		target = r.URL.String()
+		w.Write([]byte(`{"response":{"id":1}}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL := strings.Replace(proxy.URL, "http://", "http://user:pass@", 1)
+	client := NewClient("key", WithBaseURL("http://localhost:1"), WithProxy(proxyURL))
+	_, err := client.GetRecord(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:1/dns/rr/1", target)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", auth)
+
+	_, err = NewClient("key", WithProxy("proxy.example.com:3128")).GetRecord(1)
+	assert.ErrorContains(t, err, "invalid proxy URL")
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithMiddleware(t *testing.T) {
+	var order []string
+	mw := func(name string) func(http.RoundTripper) http.RoundTripper {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	hc := &http.Client{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":{"id":1}}`))
+	}, WithMiddleware(mw("inner")), WithHTTPClient(hc), WithMiddleware(mw("outer")))
+
+	_, err := client.GetRecord(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Nil(t, hc.Transport, "the caller's client must not change")
+}
+
+func TestConnectionPoolOptions(t *testing.T) {
+	client := NewClient("key")
+	tr := client.Client.Transport.(*http.Transport)
+	assert.Equal(t, DefaultMaxIdleConns, tr.MaxIdleConns)
+	assert.Equal(t, DefaultMaxIdleConnsPerHost, tr.MaxIdleConnsPerHost)
+	assert.Equal(t, DefaultIdleConnTimeout, tr.IdleConnTimeout)
+	assert.NotSame(t, http.DefaultTransport, tr)
+
+	client = NewClient("key", WithMaxIdleConns(50), WithMaxIdleConnsPerHost(10), WithIdleConnTimeout(time.Minute))
+	tr = client.Client.Transport.(*http.Transport)
+	assert.Equal(t, 50, tr.MaxIdleConns)
+	assert.Equal(t, 10, tr.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Minute, tr.IdleConnTimeout)
+	client.CloseIdleConnections()
+}
@@ -0,0 +1,165 @@
+package regfishapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// pageEnvelope is a list response. Besides the "response" payload the API
+// may describe further pages either with a cursor or with page numbers.
+type pageEnvelope struct {
+	envelope
+	Response   json.RawMessage `json:"response"`
+	NextCursor string          `json:"next_cursor"`
+	Page       int             `json:"page"`
+	TotalPages int             `json:"total_pages"`
+}
+
+// getPages requests endpoint and follows its pagination until the last
+// page, calling fn with the "response" payload of each page in order. A
+// response without pagination metadata is treated as the only page.
+func (c *Client) getPages(ctx context.Context, endpoint string, fn func(json.RawMessage) error) error {
+	p := newPager(c, endpoint)
+	for {
+		raw, ok, err := p.nextPage(ctx)
+		if err != nil || !ok {
+			return err
+		}
+		if err := fn(raw); err != nil {
+			return err
+		}
+	}
+}
+
+// pager fetches the pages of a list endpoint one request at a time.
+type pager struct {
+	c        *Client
+	endpoint string
+	next     string
+	seen     map[string]bool
+}
+
+func newPager(c *Client, endpoint string) *pager {
+	return &pager{c: c, endpoint: endpoint, next: endpoint, seen: map[string]bool{}}
+}
+
+// nextPage requests the next page and returns its "response" payload. It
+// returns false once the last page has been fetched. Pages with an empty
+// payload are skipped.
+func (p *pager) nextPage(ctx context.Context) (json.RawMessage, bool, error) {
+	for p.next != "" {
+		respBody, err := p.c.RequestContext(ctx, "GET", p.next, nil, nil)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var page pageEnvelope
+		if _, err := p.c.decodeResponse(respBody, &page); err != nil {
+			return nil, false, err
+		}
+
+		switch {
+		case page.NextCursor != "":
+			if p.seen[page.NextCursor] {
+				return nil, false, fmt.Errorf("pagination of %s returned cursor %q twice", p.endpoint, page.NextCursor)
+			}
+			p.seen[page.NextCursor] = true
+			p.next = withQuery(p.endpoint, "cursor", page.NextCursor)
+		case page.Page > 0 && page.Page < page.TotalPages:
+			p.next = withQuery(p.endpoint, "page", strconv.Itoa(page.Page+1))
+		default:
+			p.next = ""
+		}
+		if len(page.Response) > 0 {
+			return page.Response, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// withQuery appends key=value to the query string of endpoint.
+func withQuery(endpoint, key, value string) string {
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+	return endpoint + sep + url.QueryEscape(key) + "=" + url.QueryEscape(value)
+}
+
+// RecordIterator walks the records of a domain page by page, so that only
+// one page is held in memory at a time. Create one with IterateRecords.
+//
+//	it := client.IterateRecords("example.com")
+//	for rec, ok := it.Next(); ok; rec, ok = it.Next() {
+//		// use rec
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle err
+//	}
+//
+// A RecordIterator is not safe for concurrent use.
+type RecordIterator struct {
+	ctx    context.Context
+	c      *Client
+	domain string
+	pager  *pager
+	page   []Record
+	err    error
+}
+
+// IterateRecords returns an iterator over the records of domain, which
+// yields the same records as GetRecordsByDomain but requests the next
+// page only when the current one is used up.
+func (c *Client) IterateRecords(domain string) *RecordIterator {
+	return c.IterateRecordsContext(context.Background(), domain)
+}
+
+// IterateRecordsContext is like IterateRecords but uses ctx for the underlying requests.
+func (c *Client) IterateRecordsContext(ctx context.Context, domain string) *RecordIterator {
+	it := &RecordIterator{ctx: ctx, c: c, domain: domain}
+	asciiDomain, err := ToASCII(domain)
+	if err != nil {
+		it.err = fmt.Errorf("invalid domain %q: %w", domain, err)
+		return it
+	}
+	it.pager = newPager(c, fmt.Sprintf("/dns/%s/rr", asciiDomain))
+	return it
+}
+
+// Next returns the next record. It returns false when there are no more
+// records or a request failed; Err tells the two apart.
+func (it *RecordIterator) Next() (Record, bool) {
+	for len(it.page) == 0 {
+		if it.err != nil {
+			return Record{}, false
+		}
+		raw, ok, err := it.pager.nextPage(it.ctx)
+		if err != nil {
+			it.err = err
+			return Record{}, false
+		}
+		if !ok {
+			return Record{}, false
+		}
+		if err := it.c.unmarshal(raw, &it.page); err != nil {
+			it.err = fmt.Errorf("failed to unmarshal response: %w", err)
+			return Record{}, false
+		}
+	}
+
+	r := it.page[0]
+	it.page[0] = Record{}
+	it.page = it.page[1:]
+	r.Zone = it.domain
+	return it.c.finishRecord(r), true
+}
+
+// Err returns the error that stopped the iteration, or nil if all records
+// were returned.
+func (it *RecordIterator) Err() error {
+	return it.err
+}
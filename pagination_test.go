@@ -0,0 +1,90 @@
+package regfishapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRecordsByDomainCursorPagination(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Write([]byte(`{"response":[{"id":1},{"id":2}],"next_cursor":"abc"}`))
+		case "abc":
+			w.Write([]byte(`{"response":[{"id":3}],"next_cursor":"def"}`))
+		case "def":
+			w.Write([]byte(`{"response":[{"id":4}]}`))
+		}
+	})
+
+	recs, err := client.GetRecordsByDomain("example.com")
+	assert.NoError(t, err)
+	if assert.Len(t, recs, 4) {
+		assert.Equal(t, 4, recs[3].ID)
+	}
+}
+
+func TestListDomainsPagePagination(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		fmt.Fprintf(w, `{"response":[{"name":"d%d.example"}],"page":%d,"total_pages":3}`, page, page)
+	})
+
+	domains, err := client.ListDomains()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, requests)
+	if assert.Len(t, domains, 3) {
+		assert.Equal(t, "d3.example", domains[2].Name)
+	}
+}
+
+func TestPaginationCursorLoop(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":[{"id":1}],"next_cursor":"same"}`))
+	})
+
+	_, err := client.GetRecordsByDomain("example.com")
+	assert.ErrorContains(t, err, "twice")
+}
+
+func TestRecordIterator(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query().Get("cursor"))
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Write([]byte(`{"response":[{"id":1},{"id":2}],"next_cursor":"abc"}`))
+		case "abc":
+			w.Write([]byte(`{"response":[],"next_cursor":"def"}`))
+		case "def":
+			w.Write([]byte(`{"response":[{"id":3}],"next_cursor":"ghi"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	it := client.IterateRecords("example.com")
+	rec, ok := it.Next()
+	assert.True(t, ok)
+	assert.Equal(t, 1, rec.ID)
+	assert.Equal(t, "example.com", rec.Zone)
+	assert.Equal(t, []string{""}, requests, "pages are fetched lazily")
+
+	var ids []int
+	for rec, ok := it.Next(); ok; rec, ok = it.Next() {
+		ids = append(ids, rec.ID)
+	}
+	assert.Equal(t, []int{2, 3}, ids)
+	assert.ErrorContains(t, it.Err(), "cursor=ghi")
+	_, ok = it.Next()
+	assert.False(t, ok)
+}
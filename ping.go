@@ -0,0 +1,27 @@
+package regfishapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Ping checks that the API is reachable and accepts the Client's API key.
+// A rejected key yields an error matching ErrUnauthorized; failing to reach
+// the API at all yields an error that is not an *APIError.
+func (c *Client) Ping() error {
+	return c.PingContext(context.Background())
+}
+
+// PingContext is like Ping but uses ctx for the underlying request.
+func (c *Client) PingContext(ctx context.Context) error {
+	_, err := c.RequestContext(ctx, "GET", "/account", nil, nil)
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, ErrUnauthorized) {
+		return fmt.Errorf("ping: API key rejected: %w", err)
+	}
+	return fmt.Errorf("ping: %w", err)
+}
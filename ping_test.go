@@ -0,0 +1,33 @@
+package regfishapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPing(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"success":true,"response":{}}`))
+	})
+	assert.NoError(t, client.Ping())
+
+	client.APIKey = "wrong"
+	err := client.Ping()
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestPingUnreachable(t *testing.T) {
+	client := NewClient("test-key", WithBaseURL("http://127.0.0.1:1"))
+	err := client.Ping()
+	var apiErr *APIError
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &apiErr))
+	assert.NotErrorIs(t, err, ErrUnauthorized)
+}
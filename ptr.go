@@ -0,0 +1,49 @@
+package regfishapi
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// ReverseName returns the PTR owner name of ip, e.g.
+// "1.2.0.192.in-addr.arpa." for 192.0.2.1 and the nibble form under
+// ip6.arpa. for IPv6 addresses.
+func ReverseName(ip string) (string, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return "", fmt.Errorf("invalid IP address %q", ip)
+	}
+	addr = addr.Unmap()
+
+	var b strings.Builder
+	if addr.Is4() {
+		a := addr.As4()
+		for i := len(a) - 1; i >= 0; i-- {
+			fmt.Fprintf(&b, "%d.", a[i])
+		}
+		b.WriteString("in-addr.arpa.")
+		return b.String(), nil
+	}
+
+	const hexDigits = "0123456789abcdef"
+	a := addr.As16()
+	for i := len(a) - 1; i >= 0; i-- {
+		b.WriteByte(hexDigits[a[i]&0x0f])
+		b.WriteByte('.')
+		b.WriteByte(hexDigits[a[i]>>4])
+		b.WriteByte('.')
+	}
+	b.WriteString("ip6.arpa.")
+	return b.String(), nil
+}
+
+// NewPTRRecord returns a PTR record pointing the reverse name of ip at
+// target.
+func NewPTRRecord(ip, target string, ttl int) (Record, error) {
+	name, err := ReverseName(ip)
+	if err != nil {
+		return Record{}, err
+	}
+	return Record{Name: name, Type: "PTR", Data: target, TTL: optionalTTL(ttl)}, nil
+}
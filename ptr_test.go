@@ -0,0 +1,38 @@
+package regfishapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseName(t *testing.T) {
+	tests := []struct {
+		ip, want string
+	}{
+		{"192.0.2.1", "1.2.0.192.in-addr.arpa."},
+		{"::ffff:192.0.2.1", "1.2.0.192.in-addr.arpa."},
+		{"2001:db8::567:89ab", "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."},
+	}
+	for _, tt := range tests {
+		got, err := ReverseName(tt.ip)
+		assert.NoError(t, err, tt.ip)
+		assert.Equal(t, tt.want, got, tt.ip)
+	}
+
+	_, err := ReverseName("www.example.com")
+	assert.Error(t, err)
+}
+
+func TestNewPTRRecord(t *testing.T) {
+	rec, err := NewPTRRecord("192.0.2.1", "www.example.com.", 3600)
+	assert.NoError(t, err)
+	assert.Equal(t, Record{Name: "1.2.0.192.in-addr.arpa.", Type: "PTR", Data: "www.example.com.", TTL: IntPtr(3600)}, rec)
+	assert.NoError(t, rec.Validate())
+
+	_, err = NewPTRRecord("bogus", "www.example.com.", 0)
+	assert.Error(t, err)
+
+	rec.Data = "bad..name"
+	assert.Error(t, rec.Validate())
+}
@@ -0,0 +1,64 @@
+package regfishapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit is the rate-limit state reported by the API on its most recent
+// response.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is when the current window ends.
+	Reset time.Time
+	// Observed is when these values were received. It is zero if the API
+	// hasn't reported rate-limit headers yet.
+	Observed time.Time
+}
+
+// RateLimit returns the rate-limit state from the latest response that
+// carried X-RateLimit-* headers.
+func (c *Client) RateLimit() RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit
+}
+
+// parseRateLimit reads the X-RateLimit-Limit, -Remaining and -Reset headers.
+// Reset may be given either as a Unix timestamp or as seconds from now.
+func parseRateLimit(h http.Header, now time.Time) (RateLimit, bool) {
+	limit, hasLimit := headerInt(h, "X-RateLimit-Limit")
+	remaining, hasRemaining := headerInt(h, "X-RateLimit-Remaining")
+	reset, hasReset := headerInt(h, "X-RateLimit-Reset")
+	if !hasLimit && !hasRemaining && !hasReset {
+		return RateLimit{}, false
+	}
+
+	rl := RateLimit{Limit: limit, Remaining: remaining, Observed: now}
+	if hasReset {
+		// Anything before 2001-09-09 is too small to be a Unix timestamp.
+		if reset < 1e9 {
+			rl.Reset = now.Add(time.Duration(reset) * time.Second)
+		} else {
+			rl.Reset = time.Unix(int64(reset), 0)
+		}
+	}
+	return rl, true
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	v := strings.TrimSpace(h.Get(key))
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,73 @@
+package regfishapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitHeaders(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1893456000")
+		w.Write([]byte(`{"response":{}}`))
+	})
+
+	assert.True(t, client.RateLimit().Observed.IsZero())
+
+	_, err := client.GetRecord(1)
+	assert.NoError(t, err)
+
+	rl := client.RateLimit()
+	assert.Equal(t, 100, rl.Limit)
+	assert.Equal(t, 42, rl.Remaining)
+	assert.Equal(t, time.Unix(1893456000, 0), rl.Reset)
+	assert.False(t, rl.Observed.IsZero())
+}
+
+func TestParseRateLimitRelativeReset(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	h := http.Header{}
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", "30")
+
+	rl, ok := parseRateLimit(h, now)
+	assert.True(t, ok)
+	assert.Equal(t, 0, rl.Remaining)
+	assert.Equal(t, now.Add(30*time.Second), rl.Reset)
+
+	_, ok = parseRateLimit(http.Header{}, now)
+	assert.False(t, ok)
+}
+
+func TestWithRateLimit(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":{}}`))
+	}, WithRateLimit(20, 1))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.GetRecord(1)
+		assert.NoError(t, err)
+	}
+	// The first request uses the burst token; the next two wait ~50ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestWithRateLimitRespectsContext(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":{}}`))
+	}, WithRateLimit(0.1, 1))
+
+	_, err := client.GetRecord(1)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.GetRecordContext(ctx, 1)
+	assert.Error(t, err)
+}
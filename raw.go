@@ -0,0 +1,76 @@
+package regfishapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Do sends a request to endpoint, relative to the BaseURL, and returns the
+// unparsed "response" object of the reply. body, if not nil, is encoded as
+// JSON. Do is an escape hatch for endpoints and fields the typed methods
+// don't cover; it applies the same authentication, retries and error
+// handling.
+func (c *Client) Do(method, endpoint string, body interface{}) (json.RawMessage, error) {
+	return c.DoContext(context.Background(), method, endpoint, body)
+}
+
+// DoContext is like Do but uses ctx for the underlying request.
+func (c *Client) DoContext(ctx context.Context, method, endpoint string, body interface{}) (json.RawMessage, error) {
+	respBody, reqErr := c.RequestContext(ctx, method, endpoint, body, nil)
+	if reqErr != nil && !errors.Is(reqErr, ErrDryRun) {
+		return nil, reqErr
+	}
+
+	var response struct {
+		envelope
+		Response json.RawMessage `json:"response"`
+	}
+	if _, err := c.decodeResponse(respBody, &response); err != nil {
+		return nil, err
+	}
+	return response.Response, reqErr
+}
+
+// GetRecordRaw is like GetRecord but returns the record as sent by the
+// API, including fields Record doesn't model.
+func (c *Client) GetRecordRaw(rrid int) (json.RawMessage, error) {
+	return c.GetRecordRawContext(context.Background(), rrid)
+}
+
+// GetRecordRawContext is like GetRecordRaw but uses ctx for the underlying request.
+func (c *Client) GetRecordRawContext(ctx context.Context, rrid int) (json.RawMessage, error) {
+	raw, err := c.DoContext(ctx, "GET", fmt.Sprintf("/dns/rr/%d", rrid), nil)
+	if err != nil {
+		return nil, recordNotFound(rrid, err)
+	}
+	return raw, nil
+}
+
+// RequestRaw is like Request but returns the response without reading its
+// body, so that large payloads can be streamed, e.g. to a file. The caller
+// must close the returned response's Body. A gzip-encoded body is
+// decompressed while it is read and MaxResponseBytes doesn't apply.
+// RequestTimeout, DefaultRequestTimeout unless changed, still bounds the
+// whole exchange including reading the body, so a large export is cut off
+// after it; use a longer timeout, or zero, for such endpoints.
+//
+// Errors are handled as by Request: the request is retried as configured
+// until a successful response arrives, and a status of 400 or above is
+// returned as an *APIError without a response. With DryRun set, mutating
+// requests return ErrDryRun and no response.
+func (c *Client) RequestRaw(method, endpoint string, body interface{}, headers map[string]string) (*http.Response, error) {
+	return c.RequestRawContext(context.Background(), method, endpoint, body, headers)
+}
+
+// RequestRawContext is like RequestRaw but uses ctx for the underlying
+// request. Cancelling ctx also aborts reading the body.
+func (c *Client) RequestRawContext(ctx context.Context, method, endpoint string, body interface{}, headers map[string]string) (*http.Response, error) {
+	resp, _, err := c.exchange(ctx, method, endpoint, body, headers, true)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,97 @@
+package regfishapi
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDo(t *testing.T) {
+	var method, path string
+	var sent map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"success":true,"response":{"id":7,"dnssec":true}}`))
+	})
+
+	raw, err := client.Do(http.MethodPost, "/dns/example.com/dnssec", map[string]bool{"enabled": true})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/dns/example.com/dnssec", path)
+	assert.Equal(t, map[string]interface{}{"enabled": true}, sent)
+	assert.JSONEq(t, `{"id":7,"dnssec":true}`, string(raw))
+}
+
+func TestGetRecordRaw(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dns/rr/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"response":{"id":2,"name":"www.example.com.","type":"A","data":"192.0.2.1","created":"2024-01-01"}}`))
+	})
+
+	raw, err := client.GetRecordRaw(2)
+	assert.NoError(t, err)
+	var fields map[string]interface{}
+	assert.NoError(t, json.Unmarshal(raw, &fields))
+	assert.Equal(t, "2024-01-01", fields["created"])
+
+	_, err = client.GetRecordRaw(3)
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestRequestRaw(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte("example.com. 3600 IN NS ns1.regfish.de.\n"))
+		gz.Close()
+	})
+	client.MaxRetries = 1
+	client.RetryBackoff = noBackoff
+	client.RequestTimeout = time.Minute
+
+	resp, err := client.RequestRaw(http.MethodGet, "/dns/example.com/export", nil, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, 2, attempts)
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com. 3600 IN NS ns1.regfish.de.\n", string(body))
+}
+
+func TestRequestRawError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"success":false,"message":"not found"}`))
+	})
+
+	resp, err := client.RequestRaw(http.MethodGet, "/dns/example.com/export", nil, nil)
+	assert.Nil(t, resp)
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	}
+
+	client.DryRun = true
+	_, err = client.RequestRaw(http.MethodDelete, "/dns/rr/1", nil, nil)
+	assert.ErrorIs(t, err, ErrDryRun)
+}
@@ -0,0 +1,69 @@
+package regfishapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IntPtr returns a pointer to v, for setting optional Record fields such
+// as TTL and Priority.
+func IntPtr(v int) *int {
+	return &v
+}
+
+// StringPtr returns a pointer to v, for setting optional Record fields
+// such as Annotation and Tag.
+func StringPtr(v string) *string {
+	return &v
+}
+
+// optionalTTL returns the TTL field for the ttl argument of a record
+// constructor, where 0 means unset.
+func optionalTTL(ttl int) *int {
+	if ttl == 0 {
+		return nil
+	}
+	return &ttl
+}
+
+// NewARecord returns an A record pointing name at the IPv4 address ip.
+func NewARecord(name, ip string, ttl int) Record {
+	return Record{Name: name, Type: "A", Data: ip, TTL: optionalTTL(ttl)}
+}
+
+// NewAAAARecord returns an AAAA record pointing name at the IPv6 address ip.
+func NewAAAARecord(name, ip string, ttl int) Record {
+	return Record{Name: name, Type: "AAAA", Data: ip, TTL: optionalTTL(ttl)}
+}
+
+// NewCNAMERecord returns a CNAME record aliasing name to target.
+func NewCNAMERecord(name, target string, ttl int) Record {
+	return Record{Name: name, Type: "CNAME", Data: target, TTL: optionalTTL(ttl)}
+}
+
+// NewMXRecord returns an MX record routing mail for name to target with the
+// given preference. A priority of 0 is valid and is kept.
+func NewMXRecord(name string, priority int, target string, ttl int) Record {
+	return Record{Name: name, Type: "MX", Data: target, TTL: optionalTTL(ttl), Priority: &priority}
+}
+
+// NewCAARecord returns a CAA record with the given flags (0 or
+// CAAFlagCritical), property tag (CAATagIssue, CAATagIssueWild or
+// CAATagIODEF) and value, e.g. "letsencrypt.org" or "mailto:security@example.com".
+func NewCAARecord(name string, flags int, tag, value string, ttl int) Record {
+	return Record{Name: name, Type: "CAA", Data: value, TTL: optionalTTL(ttl), Flags: &flags, Tag: &tag}
+}
+
+// NewSRVRecord returns an SRV record for service and proto under name, e.g.
+// NewSRVRecord("sip", "tcp", "example.com.", 10, 60, 5060, "sip.example.com.", 0)
+// is named "_sip._tcp.example.com.". Service and proto may be given with or
+// without their leading underscore.
+func NewSRVRecord(service, proto, name string, priority, weight, port int, target string, ttl int) Record {
+	return Record{
+		Name:     fmt.Sprintf("_%s._%s.%s", strings.TrimPrefix(service, "_"), strings.TrimPrefix(proto, "_"), name),
+		Type:     "SRV",
+		Data:     fmt.Sprintf("%d %d %s", weight, port, target),
+		TTL:      optionalTTL(ttl),
+		Priority: &priority,
+	}
+}
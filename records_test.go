@@ -0,0 +1,101 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordConstructors(t *testing.T) {
+	a := NewARecord("www.example.com.", "192.0.2.1", 300)
+	assert.Equal(t, Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(300)}, a)
+
+	aaaa := NewAAAARecord("www.example.com.", "2001:db8::1", 0)
+	assert.Equal(t, "AAAA", aaaa.Type)
+
+	cname := NewCNAMERecord("ftp.example.com.", "www.example.com.", 0)
+	assert.Equal(t, "CNAME", cname.Type)
+	assert.Equal(t, "www.example.com.", cname.Data)
+
+	mx := NewMXRecord("example.com.", 10, "mail.example.com.", 0)
+	assert.Equal(t, "MX", mx.Type)
+	if assert.NotNil(t, mx.Priority) {
+		assert.Equal(t, 10, *mx.Priority)
+	}
+	assert.NoError(t, mx.Validate())
+
+	caa := NewCAARecord("example.com.", 0, "issue", "letsencrypt.org", 0)
+	assert.Equal(t, "CAA", caa.Type)
+	if assert.NotNil(t, caa.Flags) && assert.NotNil(t, caa.Tag) {
+		assert.Equal(t, 0, *caa.Flags)
+		assert.Equal(t, "issue", *caa.Tag)
+	}
+}
+
+func TestNewSRVRecord(t *testing.T) {
+	srv := NewSRVRecord("sip", "_tcp", "example.com.", 10, 60, 5060, "sip.example.com.", 0)
+	assert.Equal(t, "_sip._tcp.example.com.", srv.Name)
+	assert.Equal(t, "SRV", srv.Type)
+	assert.Equal(t, "60 5060 sip.example.com.", srv.Data)
+	if assert.NotNil(t, srv.Priority) {
+		assert.Equal(t, 10, *srv.Priority)
+	}
+	assert.NoError(t, srv.Validate())
+}
+
+func TestRecordTTLWire(t *testing.T) {
+	data, err := json.Marshal(Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "ttl")
+
+	data, err = json.Marshal(Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(0)})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"ttl":0`)
+
+	var rec Record
+	assert.NoError(t, json.Unmarshal([]byte(`{"ttl":0}`), &rec))
+	assert.Equal(t, IntPtr(0), rec.TTL)
+
+	assert.Nil(t, NewARecord("www.example.com.", "192.0.2.1", 0).TTL)
+	assert.NoError(t, Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(0)}.Validate())
+}
+
+func TestPriorityZeroSurvives(t *testing.T) {
+	mx := NewMXRecord("example.com.", 0, "mail.example.com.", 0)
+	srv := NewSRVRecord("sip", "tcp", "example.com.", 0, 0, 5060, "sip.example.com.", 0)
+	for _, rec := range []Record{mx, srv} {
+		data, err := json.Marshal(rec)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `"priority":0`, rec.Type)
+
+		var decoded Record
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, IntPtr(0), decoded.Priority, rec.Type)
+		assert.NoError(t, decoded.Validate(), rec.Type)
+	}
+
+	var sent map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"response":{"id":1,"priority":0}}`))
+	})
+	created, err := client.CreateRecord(mx)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), sent["priority"])
+	assert.Equal(t, IntPtr(0), created.Priority)
+
+	parsed, err := ParseZonefile(strings.NewReader(zonefileLine("example.com.", mx)+"\n"), "example.com.")
+	assert.NoError(t, err)
+	if assert.Len(t, parsed, 1) {
+		assert.Equal(t, IntPtr(0), parsed[0].Priority)
+	}
+
+	have := NewMXRecord("example.com.", 10, "mail.example.com.", 0)
+	_, toUpdate, _ := Diff([]Record{mx}, []Record{have})
+	if assert.Len(t, toUpdate, 1) {
+		assert.Equal(t, IntPtr(0), toUpdate[0].Priority)
+	}
+}
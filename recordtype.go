@@ -0,0 +1,57 @@
+package regfishapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RecordType is a DNS resource record type such as "A" or "MX". Record.Type
+// is a plain string; convert with RecordType(r.Type) or string(t).
+type RecordType string
+
+// The record types known to Record.Validate.
+const (
+	RecordTypeA      RecordType = "A"
+	RecordTypeAAAA   RecordType = "AAAA"
+	RecordTypeCAA    RecordType = "CAA"
+	RecordTypeCNAME  RecordType = "CNAME"
+	RecordTypeDNSKEY RecordType = "DNSKEY"
+	RecordTypeDS     RecordType = "DS"
+	RecordTypeMX     RecordType = "MX"
+	RecordTypeNS     RecordType = "NS"
+	RecordTypePTR    RecordType = "PTR"
+	RecordTypeSOA    RecordType = "SOA"
+	RecordTypeSRV    RecordType = "SRV"
+	RecordTypeSSHFP  RecordType = "SSHFP"
+	RecordTypeTLSA   RecordType = "TLSA"
+	RecordTypeTXT    RecordType = "TXT"
+)
+
+// knownTypes lists the resource record types accepted by Record.Validate.
+var knownTypes = map[RecordType]bool{
+	RecordTypeA: true, RecordTypeAAAA: true, RecordTypeCAA: true, RecordTypeCNAME: true,
+	RecordTypeDNSKEY: true, RecordTypeDS: true, RecordTypeMX: true, RecordTypeNS: true,
+	RecordTypePTR: true, RecordTypeSOA: true, RecordTypeSRV: true, RecordTypeSSHFP: true,
+	RecordTypeTLSA: true, RecordTypeTXT: true,
+}
+
+// Canonical returns t in upper case without surrounding whitespace, the
+// form sent to the API, e.g. "CNAME" for "Cname".
+func (t RecordType) Canonical() RecordType {
+	return RecordType(strings.ToUpper(strings.TrimSpace(string(t))))
+}
+
+// IsValid reports whether t is one of the known record types, ignoring case.
+func (t RecordType) IsValid() bool {
+	return knownTypes[t.Canonical()]
+}
+
+// ParseRecordType returns the canonical RecordType for s, accepting any
+// case, or an error if s isn't a known type.
+func ParseRecordType(s string) (RecordType, error) {
+	t := RecordType(s).Canonical()
+	if !knownTypes[t] {
+		return "", fmt.Errorf("unknown record type %q", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,34 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordType(t *testing.T) {
+	assert.True(t, RecordTypeCNAME.IsValid())
+	assert.True(t, RecordType("Cname").IsValid())
+	assert.False(t, RecordType("BOGUS").IsValid())
+	assert.Equal(t, RecordTypeTXT, RecordType(" txt ").Canonical())
+
+	rt, err := ParseRecordType("aaaa")
+	assert.NoError(t, err)
+	assert.Equal(t, RecordTypeAAAA, rt)
+	_, err = ParseRecordType("A6")
+	assert.ErrorContains(t, err, `unknown record type "A6"`)
+}
+
+func TestCreateRecordCanonicalizesType(t *testing.T) {
+	var sent Record
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"response":{}}`))
+	})
+
+	_, err := client.CreateRecord(Record{Name: "www.example.com.", Type: "Cname", Data: "web.example.com."})
+	assert.NoError(t, err)
+	assert.Equal(t, "CNAME", sent.Type)
+}
@@ -0,0 +1,40 @@
+package regfishapi
+
+import (
+	"bytes"
+	"strings"
+)
+
+// redact replaces every occurrence of the API key in s.
+func (c *Client) redact(s string) string {
+	if c.APIKey == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, c.APIKey, redacted)
+}
+
+// redactBytes is like redact for byte slices.
+func (c *Client) redactBytes(b []byte) []byte {
+	if c.APIKey == "" || !bytes.Contains(b, []byte(c.APIKey)) {
+		return b
+	}
+	return bytes.ReplaceAll(b, []byte(c.APIKey), []byte(redacted))
+}
+
+// redactError hides the API key in the message of err while keeping err
+// available to errors.Is and errors.As.
+func (c *Client) redactError(err error) error {
+	if err == nil || c.APIKey == "" || !strings.Contains(err.Error(), c.APIKey) {
+		return err
+	}
+	return &redactedError{msg: c.redact(err.Error()), err: err}
+}
+
+// redactedError is an error whose message has been scrubbed of the API key.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
@@ -0,0 +1,47 @@
+package regfishapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const secretKey = "sk-0123456789abcdef"
+
+func TestAPIKeyIsRedacted(t *testing.T) {
+	var log bytes.Buffer
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// A misbehaving server echoing the key back in its error message.
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"message":"key %s is invalid","error":"%s"}`, r.Header.Get("x-api-key"), r.Header.Get("x-api-key"))
+	}, WithDebug(&log))
+	client.APIKey = secretKey
+
+	_, err := client.GetRecord(1)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.NotContains(t, err.Error(), secretKey)
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.NotContains(t, apiErr.Message, secretKey)
+		assert.NotContains(t, apiErr.Reason, secretKey)
+		assert.NotContains(t, string(apiErr.Body), secretKey)
+	}
+	assert.NotContains(t, log.String(), secretKey)
+	assert.Contains(t, log.String(), redacted)
+}
+
+func TestAPIKeyIsRedactedFromTransportErrors(t *testing.T) {
+	var log bytes.Buffer
+	// A key accidentally pasted into the base URL ends up in url.Error.
+	client := NewClient(secretKey, WithBaseURL("http://127.0.0.1:1/"+secretKey), WithDebug(&log))
+
+	_, err := client.GetRecord(1)
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), secretKey)
+	assert.NotContains(t, log.String(), secretKey)
+}
@@ -0,0 +1,146 @@
+// Package regfishacme solves ACME DNS-01 challenges, e.g. for Let's
+// Encrypt, with Regfish DNS. Provider implements the challenge.Provider
+// and challenge.ProviderTimeout interfaces of github.com/go-acme/lego
+// without depending on it:
+//
+//	provider := regfishacme.NewProvider(regfishapi.NewClient(apiKey))
+//	err := legoClient.Challenge.SetDNS01Provider(provider)
+package regfishacme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	regfishapi "github.com/regfish/regfish-dnsapi-go"
+)
+
+// DefaultTTL is the TTL of the challenge records unless Provider.TTL is set.
+const DefaultTTL = 60
+
+// Provider creates and removes the _acme-challenge TXT records for DNS-01
+// challenges. It is safe for concurrent use.
+type Provider struct {
+	Client regfishapi.DNSClient
+	// TTL is the TTL of the challenge records. Zero means DefaultTTL.
+	TTL int
+	// PropagationTimeout and PollingInterval are reported by Timeout for
+	// the ACME client's propagation check. Zero means
+	// regfishapi.DefaultWaitTimeout and regfishapi.DefaultWaitInterval.
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+
+	mu      sync.Mutex
+	created map[string]int
+}
+
+// NewProvider returns a Provider backed by client.
+func NewProvider(client regfishapi.DNSClient) *Provider {
+	return &Provider{Client: client}
+}
+
+// ChallengeRecord returns the name and value of the TXT record that proves
+// control of domain for the key authorization keyAuth, as specified in
+// RFC 8555 section 8.4. A leading "*." of wildcard domains is dropped.
+func ChallengeRecord(domain, keyAuth string) (name, value string) {
+	domain = strings.TrimSuffix(strings.TrimPrefix(domain, "*."), ".")
+	sum := sha256.Sum256([]byte(keyAuth))
+	return "_acme-challenge." + domain + ".", base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Present creates the challenge TXT record for domain.
+func (p *Provider) Present(domain, token, keyAuth string) error {
+	return p.PresentContext(context.Background(), domain, token, keyAuth)
+}
+
+// PresentContext is like Present but uses ctx for the underlying request.
+func (p *Provider) PresentContext(ctx context.Context, domain, token, keyAuth string) error {
+	name, value := ChallengeRecord(domain, keyAuth)
+	ttl := p.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	rec, err := p.Client.CreateRecordContext(ctx, regfishapi.NewTXTRecord(name, value, ttl))
+	if err != nil {
+		return fmt.Errorf("regfishacme: present %s: %w", name, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.created == nil {
+		p.created = make(map[string]int)
+	}
+	p.created[name+" "+value] = rec.ID
+	return nil
+}
+
+// CleanUp removes the challenge TXT record created by Present. Records
+// presented by another process are looked up in the zone of domain, which
+// is found by trying domain and its parent domains in turn.
+func (p *Provider) CleanUp(domain, token, keyAuth string) error {
+	return p.CleanUpContext(context.Background(), domain, token, keyAuth)
+}
+
+// CleanUpContext is like CleanUp but uses ctx for the underlying requests.
+func (p *Provider) CleanUpContext(ctx context.Context, domain, token, keyAuth string) error {
+	name, value := ChallengeRecord(domain, keyAuth)
+	key := name + " " + value
+
+	p.mu.Lock()
+	rrid, ok := p.created[key]
+	p.mu.Unlock()
+	if !ok {
+		var err error
+		if rrid, err = p.lookup(ctx, name, value); err != nil {
+			return fmt.Errorf("regfishacme: clean up %s: %w", name, err)
+		}
+	}
+
+	if _, err := p.Client.DeleteRecordContext(ctx, rrid); err != nil && !errors.Is(err, regfishapi.ErrNotFound) {
+		return fmt.Errorf("regfishacme: clean up %s: %w", name, err)
+	}
+	p.mu.Lock()
+	delete(p.created, key)
+	p.mu.Unlock()
+	return nil
+}
+
+// Timeout implements lego's challenge.ProviderTimeout.
+func (p *Provider) Timeout() (timeout, interval time.Duration) {
+	timeout, interval = p.PropagationTimeout, p.PollingInterval
+	if timeout == 0 {
+		timeout = regfishapi.DefaultWaitTimeout
+	}
+	if interval == 0 {
+		interval = regfishapi.DefaultWaitInterval
+	}
+	return timeout, interval
+}
+
+// lookup finds the RRID of the TXT record name with value, searching the
+// zones name could belong to from the most to the least specific.
+func (p *Provider) lookup(ctx context.Context, name, value string) (int, error) {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	for i := 1; i < len(labels)-1; i++ {
+		zone := strings.Join(labels[i:], ".")
+		records, err := p.Client.FindRecordsContext(ctx, zone, name, "TXT")
+		if errors.Is(err, regfishapi.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		for _, r := range records {
+			if r.TXTValue() == value {
+				return r.ID, nil
+			}
+		}
+		return 0, regfishapi.ErrRecordNotFound
+	}
+	return 0, regfishapi.ErrRecordNotFound
+}
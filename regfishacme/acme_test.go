@@ -0,0 +1,99 @@
+package regfishacme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	regfishapi "github.com/regfish/regfish-dnsapi-go"
+	"github.com/regfish/regfish-dnsapi-go/regfishmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChallengeRecord(t *testing.T) {
+	sum := sha256.Sum256([]byte("token.thumbprint"))
+	want := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	name, value := ChallengeRecord("www.example.com", "token.thumbprint")
+	assert.Equal(t, "_acme-challenge.www.example.com.", name)
+	assert.Equal(t, want, value)
+
+	name, _ = ChallengeRecord("*.example.com.", "token.thumbprint")
+	assert.Equal(t, "_acme-challenge.example.com.", name)
+}
+
+func TestPresentCleanUp(t *testing.T) {
+	var created regfishapi.Record
+	var deleted int
+	mock := &regfishmock.Client{
+		CreateRecordFunc: func(ctx context.Context, record regfishapi.Record) (regfishapi.Record, error) {
+			created = record
+			record.ID = 7
+			return record, nil
+		},
+		DeleteRecordFunc: func(ctx context.Context, rrid int) (regfishapi.Record, error) {
+			deleted = rrid
+			return regfishapi.Record{ID: rrid}, nil
+		},
+	}
+	p := NewProvider(mock)
+
+	assert.NoError(t, p.Present("example.com", "token", "token.thumbprint"))
+	name, value := ChallengeRecord("example.com", "token.thumbprint")
+	assert.Equal(t, name, created.Name)
+	assert.Equal(t, "TXT", created.Type)
+	assert.Equal(t, value, created.TXTValue())
+	assert.Equal(t, DefaultTTL, *created.TTL)
+
+	assert.NoError(t, p.CleanUp("example.com", "token", "token.thumbprint"))
+	assert.Equal(t, 7, deleted)
+}
+
+func TestCleanUpLooksUpZone(t *testing.T) {
+	name, value := ChallengeRecord("www.sub.example.com", "keyauth")
+	var zones []string
+	var deleted int
+	mock := &regfishmock.Client{
+		FindRecordsFunc: func(ctx context.Context, domain, n, recordType string) ([]regfishapi.Record, error) {
+			zones = append(zones, domain)
+			if domain != "example.com" {
+				return nil, fmt.Errorf("get records: %w", &regfishapi.APIError{StatusCode: 404})
+			}
+			return []regfishapi.Record{
+				{ID: 1, Name: name, Type: "TXT", Data: `"other"`},
+				{ID: 2, Name: name, Type: "TXT", Data: `"` + value + `"`},
+			}, nil
+		},
+		DeleteRecordFunc: func(ctx context.Context, rrid int) (regfishapi.Record, error) {
+			deleted = rrid
+			return regfishapi.Record{ID: rrid}, nil
+		},
+	}
+
+	assert.NoError(t, NewProvider(mock).CleanUp("www.sub.example.com", "token", "keyauth"))
+	assert.Equal(t, []string{"www.sub.example.com", "sub.example.com", "example.com"}, zones)
+	assert.Equal(t, 2, deleted)
+}
+
+func TestCleanUpMissing(t *testing.T) {
+	mock := &regfishmock.Client{
+		FindRecordsFunc: func(ctx context.Context, domain, name, recordType string) ([]regfishapi.Record, error) {
+			return nil, nil
+		},
+	}
+	err := NewProvider(mock).CleanUp("example.com", "token", "keyauth")
+	assert.ErrorIs(t, err, regfishapi.ErrRecordNotFound)
+}
+
+func TestTimeout(t *testing.T) {
+	timeout, interval := (&Provider{}).Timeout()
+	assert.Equal(t, regfishapi.DefaultWaitTimeout, timeout)
+	assert.Equal(t, regfishapi.DefaultWaitInterval, interval)
+
+	timeout, interval = (&Provider{PropagationTimeout: time.Minute, PollingInterval: time.Second}).Timeout()
+	assert.Equal(t, time.Minute, timeout)
+	assert.Equal(t, time.Second, interval)
+}
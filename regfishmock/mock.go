@@ -0,0 +1,196 @@
+// Package regfishmock provides a mock implementation of
+// regfishapi.DNSClient for use in tests.
+package regfishmock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	regfishapi "github.com/regfish/regfish-dnsapi-go"
+)
+
+// Client implements regfishapi.DNSClient by calling the function fields.
+// Methods without a context call their Context counterpart with
+// context.Background(). Calling a method whose function is nil returns an
+// error. Every call is recorded in Calls.
+type Client struct {
+	GetRecordFunc          func(ctx context.Context, rrid int) (regfishapi.Record, error)
+	CreateRecordFunc       func(ctx context.Context, record regfishapi.Record) (regfishapi.Record, error)
+	UpdateRecordFunc       func(ctx context.Context, record regfishapi.Record) (regfishapi.Record, error)
+	UpdateRecordByIdFunc   func(ctx context.Context, rrid int, record regfishapi.Record) (regfishapi.Record, error)
+	DeleteRecordFunc       func(ctx context.Context, rrid int) (regfishapi.Record, error)
+	GetRecordsByDomainFunc func(ctx context.Context, domain string) ([]regfishapi.Record, error)
+	FindRecordsFunc        func(ctx context.Context, domain, name, recordType string) ([]regfishapi.Record, error)
+	UpsertRecordFunc       func(ctx context.Context, domain string, record regfishapi.Record) (regfishapi.Record, error)
+	ListDomainsFunc        func(ctx context.Context) ([]regfishapi.Domain, error)
+	PingFunc               func(ctx context.Context) error
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Call records one invocation of a Client method.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+var _ regfishapi.DNSClient = (*Client)(nil)
+
+// Calls returns the calls made so far, in order.
+func (m *Client) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+func (m *Client) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+	m.mu.Unlock()
+}
+
+func notImplemented(method string) error {
+	return fmt.Errorf("regfishmock: %s not implemented", method)
+}
+
+// GetRecord implements regfishapi.DNSClient.
+func (m *Client) GetRecord(rrid int) (regfishapi.Record, error) {
+	return m.GetRecordContext(context.Background(), rrid)
+}
+
+// GetRecordContext implements regfishapi.DNSClient.
+func (m *Client) GetRecordContext(ctx context.Context, rrid int) (regfishapi.Record, error) {
+	m.record("GetRecord", rrid)
+	if m.GetRecordFunc == nil {
+		return regfishapi.Record{}, notImplemented("GetRecord")
+	}
+	return m.GetRecordFunc(ctx, rrid)
+}
+
+// CreateRecord implements regfishapi.DNSClient.
+func (m *Client) CreateRecord(record regfishapi.Record) (regfishapi.Record, error) {
+	return m.CreateRecordContext(context.Background(), record)
+}
+
+// CreateRecordContext implements regfishapi.DNSClient.
+func (m *Client) CreateRecordContext(ctx context.Context, record regfishapi.Record) (regfishapi.Record, error) {
+	m.record("CreateRecord", record)
+	if m.CreateRecordFunc == nil {
+		return regfishapi.Record{}, notImplemented("CreateRecord")
+	}
+	return m.CreateRecordFunc(ctx, record)
+}
+
+// UpdateRecord implements regfishapi.DNSClient.
+func (m *Client) UpdateRecord(record regfishapi.Record) (regfishapi.Record, error) {
+	return m.UpdateRecordContext(context.Background(), record)
+}
+
+// UpdateRecordContext implements regfishapi.DNSClient.
+func (m *Client) UpdateRecordContext(ctx context.Context, record regfishapi.Record) (regfishapi.Record, error) {
+	m.record("UpdateRecord", record)
+	if m.UpdateRecordFunc == nil {
+		return regfishapi.Record{}, notImplemented("UpdateRecord")
+	}
+	return m.UpdateRecordFunc(ctx, record)
+}
+
+// UpdateRecordById implements regfishapi.DNSClient.
+func (m *Client) UpdateRecordById(rrid int, record regfishapi.Record) (regfishapi.Record, error) {
+	return m.UpdateRecordByIdContext(context.Background(), rrid, record)
+}
+
+// UpdateRecordByIdContext implements regfishapi.DNSClient.
+func (m *Client) UpdateRecordByIdContext(ctx context.Context, rrid int, record regfishapi.Record) (regfishapi.Record, error) {
+	m.record("UpdateRecordById", rrid, record)
+	if m.UpdateRecordByIdFunc == nil {
+		return regfishapi.Record{}, notImplemented("UpdateRecordById")
+	}
+	return m.UpdateRecordByIdFunc(ctx, rrid, record)
+}
+
+// DeleteRecord implements regfishapi.DNSClient.
+func (m *Client) DeleteRecord(rrid int) (regfishapi.Record, error) {
+	return m.DeleteRecordContext(context.Background(), rrid)
+}
+
+// DeleteRecordContext implements regfishapi.DNSClient.
+func (m *Client) DeleteRecordContext(ctx context.Context, rrid int) (regfishapi.Record, error) {
+	m.record("DeleteRecord", rrid)
+	if m.DeleteRecordFunc == nil {
+		return regfishapi.Record{}, notImplemented("DeleteRecord")
+	}
+	return m.DeleteRecordFunc(ctx, rrid)
+}
+
+// GetRecordsByDomain implements regfishapi.DNSClient.
+func (m *Client) GetRecordsByDomain(domain string) ([]regfishapi.Record, error) {
+	return m.GetRecordsByDomainContext(context.Background(), domain)
+}
+
+// GetRecordsByDomainContext implements regfishapi.DNSClient.
+func (m *Client) GetRecordsByDomainContext(ctx context.Context, domain string) ([]regfishapi.Record, error) {
+	m.record("GetRecordsByDomain", domain)
+	if m.GetRecordsByDomainFunc == nil {
+		return nil, notImplemented("GetRecordsByDomain")
+	}
+	return m.GetRecordsByDomainFunc(ctx, domain)
+}
+
+// FindRecords implements regfishapi.DNSClient.
+func (m *Client) FindRecords(domain, name, recordType string) ([]regfishapi.Record, error) {
+	return m.FindRecordsContext(context.Background(), domain, name, recordType)
+}
+
+// FindRecordsContext implements regfishapi.DNSClient.
+func (m *Client) FindRecordsContext(ctx context.Context, domain, name, recordType string) ([]regfishapi.Record, error) {
+	m.record("FindRecords", domain, name, recordType)
+	if m.FindRecordsFunc == nil {
+		return nil, notImplemented("FindRecords")
+	}
+	return m.FindRecordsFunc(ctx, domain, name, recordType)
+}
+
+// UpsertRecord implements regfishapi.DNSClient.
+func (m *Client) UpsertRecord(domain string, record regfishapi.Record) (regfishapi.Record, error) {
+	return m.UpsertRecordContext(context.Background(), domain, record)
+}
+
+// UpsertRecordContext implements regfishapi.DNSClient.
+func (m *Client) UpsertRecordContext(ctx context.Context, domain string, record regfishapi.Record) (regfishapi.Record, error) {
+	m.record("UpsertRecord", domain, record)
+	if m.UpsertRecordFunc == nil {
+		return regfishapi.Record{}, notImplemented("UpsertRecord")
+	}
+	return m.UpsertRecordFunc(ctx, domain, record)
+}
+
+// ListDomains implements regfishapi.DNSClient.
+func (m *Client) ListDomains() ([]regfishapi.Domain, error) {
+	return m.ListDomainsContext(context.Background())
+}
+
+// ListDomainsContext implements regfishapi.DNSClient.
+func (m *Client) ListDomainsContext(ctx context.Context) ([]regfishapi.Domain, error) {
+	m.record("ListDomains")
+	if m.ListDomainsFunc == nil {
+		return nil, notImplemented("ListDomains")
+	}
+	return m.ListDomainsFunc(ctx)
+}
+
+// Ping implements regfishapi.DNSClient.
+func (m *Client) Ping() error {
+	return m.PingContext(context.Background())
+}
+
+// PingContext implements regfishapi.DNSClient.
+func (m *Client) PingContext(ctx context.Context) error {
+	m.record("Ping")
+	if m.PingFunc == nil {
+		return notImplemented("Ping")
+	}
+	return m.PingFunc(ctx)
+}
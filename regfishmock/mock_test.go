@@ -0,0 +1,30 @@
+package regfishmock
+
+import (
+	"context"
+	"testing"
+
+	regfishapi "github.com/regfish/regfish-dnsapi-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	m := &Client{
+		GetRecordFunc: func(ctx context.Context, rrid int) (regfishapi.Record, error) {
+			return regfishapi.Record{ID: rrid, Name: "www.example.com."}, nil
+		},
+	}
+
+	var c regfishapi.DNSClient = m
+	rec, err := c.GetRecord(5)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, rec.ID)
+
+	_, err = c.DeleteRecord(5)
+	assert.EqualError(t, err, "regfishmock: DeleteRecord not implemented")
+
+	assert.Equal(t, []Call{
+		{Method: "GetRecord", Args: []interface{}{5}},
+		{Method: "DeleteRecord", Args: []interface{}{5}},
+	}, m.Calls())
+}
@@ -0,0 +1,166 @@
+// Package regfishotel adds OpenTelemetry tracing to a regfishapi.Client.
+// It is a separate module, github.com/regfish/regfish-dnsapi-go/regfishotel,
+// so that users who don't trace don't depend on OpenTelemetry.
+//
+//	client := regfishapi.NewClient(apiKey, regfishotel.WithTracing())
+package regfishotel
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	regfishapi "github.com/regfish/regfish-dnsapi-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/regfish/regfish-dnsapi-go/regfishotel"
+
+// Attribute keys set on the spans besides the standard HTTP ones.
+const (
+	// EndpointKey is the API path with IDs and domains replaced by
+	// placeholders, e.g. "/dns/rr/{rrid}".
+	EndpointKey = attribute.Key("regfish.endpoint")
+	// RecordTypeKey is the type of the record sent with the request.
+	RecordTypeKey = attribute.Key("dns.record.type")
+)
+
+type config struct {
+	provider    trace.TracerProvider
+	propagators propagation.TextMapPropagator
+}
+
+// Option configures the tracing set up by WithTracing and NewTransport.
+type Option func(*config)
+
+// WithTracerProvider makes spans come from tp instead of the global
+// TracerProvider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = tp
+	}
+}
+
+// WithPropagators makes the trace context be injected into requests with
+// p instead of the global TextMapPropagator.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+// WithTracing returns a regfishapi.Option that makes the Client record a
+// span for every HTTP request, including retries, and propagate the trace
+// context of the request's context in its headers.
+func WithTracing(opts ...Option) regfishapi.Option {
+	return regfishapi.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return NewTransport(next, opts...)
+	})
+}
+
+// NewTransport returns an http.RoundTripper that traces requests to the
+// Regfish API before passing them to base. A span lasts until the response
+// headers have been received. It is named after the method and endpoint
+// and carries the method, endpoint, record type of a sent record and
+// response status code as attributes.
+func NewTransport(base http.RoundTripper, opts ...Option) http.RoundTripper {
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.provider == nil {
+		cfg.provider = otel.GetTracerProvider()
+	}
+	if cfg.propagators == nil {
+		cfg.propagators = otel.GetTextMapPropagator()
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{
+		base:        base,
+		tracer:      cfg.provider.Tracer(instrumentationName, trace.WithInstrumentationVersion(regfishapi.Version)),
+		propagators: cfg.propagators,
+	}
+}
+
+type transport struct {
+	base        http.RoundTripper
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointTemplate(req.URL.Path)
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("server.address", req.URL.Hostname()),
+		EndpointKey.String(endpoint),
+	}
+	if recordType := sentRecordType(req); recordType != "" {
+		attrs = append(attrs, RecordTypeKey.String(recordType))
+	}
+
+	ctx, span := t.tracer.Start(req.Context(), "regfish "+req.Method+" "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	t.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+// endpointTemplate replaces the variable parts of an API path, so that
+// span names and attributes have a low cardinality.
+func endpointTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		switch {
+		case s != "" && strings.Trim(s, "0123456789") == "":
+			segments[i] = "{rrid}"
+		case i > 0 && segments[i-1] == "dns" && s != "rr":
+			segments[i] = "{domain}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// sentRecordType returns the type of the record in the body of req, if
+// any, without consuming the body.
+func sentRecordType(req *http.Request) string {
+	if req.GetBody == nil || req.ContentLength == 0 {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	var record struct {
+		Type string `json:"type"`
+	}
+	if err := json.NewDecoder(io.LimitReader(body, 1<<16)).Decode(&record); err != nil {
+		return ""
+	}
+	return strings.ToUpper(record.Type)
+}
@@ -0,0 +1,66 @@
+package regfishotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	regfishapi "github.com/regfish/regfish-dnsapi-go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracing(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"response":{"id":1}}`))
+	}))
+	defer srv.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := regfishapi.NewClient("key",
+		regfishapi.WithBaseURL(srv.URL),
+		WithTracing(WithTracerProvider(tp), WithPropagators(propagation.TraceContext{})),
+	)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, err := client.CreateRecordContext(ctx, regfishapi.NewARecord("www.example.com.", "192.0.2.1", 0))
+	assert.NoError(t, err)
+	_, err = client.GetRecordContext(ctx, 42)
+	assert.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 3) {
+		return
+	}
+	create, get := spans[0], spans[1]
+	assert.Equal(t, "regfish POST /dns/rr", create.Name())
+	assert.Equal(t, parent.SpanContext().TraceID(), create.SpanContext().TraceID())
+	assert.Contains(t, create.Attributes(), RecordTypeKey.String("A"))
+	assert.Contains(t, create.Attributes(), attribute.Int("http.response.status_code", 200))
+
+	assert.Equal(t, "regfish GET /dns/rr/{rrid}", get.Name())
+	assert.Contains(t, get.Attributes(), EndpointKey.String("/dns/rr/{rrid}"))
+	assert.Equal(t, codes.Error, get.Status().Code)
+
+	assert.Contains(t, traceparent, get.SpanContext().SpanID().String())
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	assert.Equal(t, "/dns/rr", endpointTemplate("/dns/rr"))
+	assert.Equal(t, "/dns/rr/{rrid}", endpointTemplate("/dns/rr/123"))
+	assert.Equal(t, "/dns/{domain}/rr", endpointTemplate("/dns/example.com/rr"))
+	assert.Equal(t, "/domain", endpointTemplate("/domain"))
+}
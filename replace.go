@@ -0,0 +1,112 @@
+package regfishapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ReplaceRecordSet makes datas the complete set of recordType records named
+// name in domain, e.g. to replace the A records of a round-robin name.
+// Records whose data is kept stay untouched unless their TTL differs from
+// ttl; a ttl of 0 keeps their TTL and leaves it unset on new records. An
+// empty datas removes the whole set.
+//
+// The API has no transactions, so the change is made make-before-break:
+// new records are created first, and if any creation fails, the ones
+// created so far are deleted again and nothing else is changed. Only
+// after all creations succeeded are the TTLs updated and the obsolete
+// records deleted. Those failures don't stop the others; the result lists
+// the changes that were applied and the error joins all failures. With
+// Client.DryRun set, the result lists the planned changes and the error is
+// ErrDryRun.
+//
+// An empty name, "@" and domain refer to the apex.
+func (c *Client) ReplaceRecordSet(domain, name, recordType string, datas []string, ttl int) (SyncResult, error) {
+	return c.ReplaceRecordSetContext(context.Background(), domain, name, recordType, datas, ttl)
+}
+
+// ReplaceRecordSetContext is like ReplaceRecordSet but uses ctx for the underlying requests.
+func (c *Client) ReplaceRecordSetContext(ctx context.Context, domain, name, recordType string, datas []string, ttl int) (SyncResult, error) {
+	name = apexName(name, domain)
+	actual, err := c.FindRecordsContext(ctx, domain, name, recordType)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	desired := make([]Record, len(datas))
+	for i, data := range datas {
+		desired[i] = Record{Name: name, Type: recordType, Data: data, TTL: optionalTTL(ttl)}
+	}
+	toCreate, toUpdate, toDelete := Diff(desired, actual)
+
+	var result SyncResult
+	if c.DryRun {
+		result.Created, result.Updated, result.Deleted = toCreate, toUpdate, toDelete
+		return result, ErrDryRun
+	}
+
+	created := make([]Record, len(toCreate))
+	createErrs := make([]error, len(toCreate))
+	started, stopErr := c.forEach(ctx, len(toCreate), func(i int) {
+		created[i], createErrs[i] = c.CreateRecordContext(ctx, toCreate[i])
+	})
+	var errs []error
+	for i, err := range createErrs[:started] {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("create %s %s %s: %w", name, recordType, toCreate[i].Data, err))
+			continue
+		}
+		result.Created = append(result.Created, created[i])
+	}
+	if stopErr != nil || len(errs) > 0 {
+		result.Created, errs = c.rollBackCreated(ctx, result.Created, errs)
+		return result, joinStopErr(stopErr, errors.Join(errs...))
+	}
+
+	updated := make([]Record, len(toUpdate))
+	updateErrs := make([]error, len(toUpdate))
+	started, stopErr = c.forEach(ctx, len(toUpdate), func(i int) {
+		updated[i], updateErrs[i] = c.UpdateRecordByIdContext(ctx, toUpdate[i].ID, toUpdate[i])
+	})
+	for i, err := range updateErrs[:started] {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("update %s %s (id %d): %w", name, recordType, toUpdate[i].ID, err))
+			continue
+		}
+		result.Updated = append(result.Updated, updated[i])
+	}
+	if stopErr != nil {
+		return result, joinStopErr(stopErr, errors.Join(errs...))
+	}
+
+	deleteErrs := make([]error, len(toDelete))
+	started, stopErr = c.forEach(ctx, len(toDelete), func(i int) {
+		deleteErrs[i] = c.deleteRecord(ctx, toDelete[i].ID)
+	})
+	for i, err := range deleteErrs[:started] {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete %s %s (id %d): %w", name, recordType, toDelete[i].ID, err))
+			continue
+		}
+		result.Deleted = append(result.Deleted, toDelete[i])
+	}
+	return result, joinStopErr(stopErr, errors.Join(errs...))
+}
+
+// rollBackCreated deletes the records created before a failure and returns
+// the ones that couldn't be deleted, with their errors appended to errs.
+// If ctx is already done, the deletes use a fresh context so the rollback
+// isn't skipped.
+func (c *Client) rollBackCreated(ctx context.Context, created []Record, errs []error) ([]Record, []error) {
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	var kept []Record
+	for _, r := range created {
+		if err := c.deleteRecord(ctx, r.ID); err != nil {
+			errs = append(errs, fmt.Errorf("roll back %s %s (id %d): %w", r.Name, r.Type, r.ID, err))
+			kept = append(kept, r)
+		}
+	}
+	return kept, errs
+}
@@ -0,0 +1,85 @@
+package regfishapi
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func webSet() *fakeZone {
+	return newFakeZone(
+		Record{ID: 1, Name: "web.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(300)},
+		Record{ID: 2, Name: "web.example.com.", Type: "A", Data: "192.0.2.2", TTL: IntPtr(300)},
+		Record{ID: 3, Name: "web.example.com.", Type: "AAAA", Data: "2001:db8::1"},
+		Record{ID: 4, Name: "mail.example.com.", Type: "A", Data: "192.0.2.9"},
+	)
+}
+
+func zoneData(z *fakeZone, name, recordType string) []string {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	var datas []string
+	for _, r := range z.records {
+		if r.Name == name && r.Type == recordType {
+			datas = append(datas, r.Data)
+		}
+	}
+	sort.Strings(datas)
+	return datas
+}
+
+func TestReplaceRecordSet(t *testing.T) {
+	zone := webSet()
+	client := newTestClient(t, zone.ServeHTTP)
+
+	result, err := client.ReplaceRecordSet("example.com", "web.example.com.", "A", []string{"192.0.2.2", "192.0.2.3"}, 0)
+	assert.NoError(t, err)
+	assert.Len(t, result.Created, 1)
+	assert.Empty(t, result.Updated)
+	if assert.Len(t, result.Deleted, 1) {
+		assert.Equal(t, 1, result.Deleted[0].ID)
+	}
+	assert.Equal(t, []string{"192.0.2.2", "192.0.2.3"}, zoneData(zone, "web.example.com.", "A"))
+	assert.Equal(t, []string{"2001:db8::1"}, zoneData(zone, "web.example.com.", "AAAA"))
+	assert.Len(t, zone.records, 4)
+
+	result, err = client.ReplaceRecordSet("example.com", "Web.example.com", "a", []string{"192.0.2.3", "192.0.2.2"}, 0)
+	assert.NoError(t, err)
+	assert.False(t, result.Changed())
+
+	result, err = client.ReplaceRecordSet("example.com", "web.example.com.", "A", []string{"192.0.2.2", "192.0.2.3"}, 60)
+	assert.NoError(t, err)
+	assert.Len(t, result.Updated, 2)
+
+	result, err = client.ReplaceRecordSet("example.com", "web.example.com.", "A", nil, 0)
+	assert.NoError(t, err)
+	assert.Len(t, result.Deleted, 2)
+	assert.Empty(t, zoneData(zone, "web.example.com.", "A"))
+}
+
+func TestReplaceRecordSetRollsBack(t *testing.T) {
+	zone := webSet()
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			if strings.Contains(string(body), "192.0.2.66") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		zone.ServeHTTP(w, r)
+	})
+	client.Concurrency = 1
+
+	result, err := client.ReplaceRecordSet("example.com", "web.example.com.", "A", []string{"192.0.2.5", "192.0.2.66"}, 0)
+	assert.ErrorContains(t, err, "create web.example.com. A 192.0.2.66")
+	assert.Empty(t, result.Created)
+	assert.Empty(t, result.Deleted)
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, zoneData(zone, "web.example.com.", "A"))
+}
@@ -0,0 +1,125 @@
+package regfishapi
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRetryBackoff is the backoff used when Client.RetryBackoff is nil:
+// exponential, starting at 500ms and capped at 30s, with jitter.
+var DefaultRetryBackoff = ExponentialBackoff(500*time.Millisecond, 30*time.Second)
+
+// ExponentialBackoff returns a backoff function that doubles base on every
+// attempt, up to max, and randomises the result within [d/2, d) so that
+// concurrent clients don't retry in lockstep.
+func ExponentialBackoff(base, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		if d <= 0 {
+			return 0
+		}
+		half := d / 2
+		return half + time.Duration(rand.Int63n(int64(d-half)+1))
+	}
+}
+
+// canRetry reports whether requests with the given method may be retried.
+func (c *Client) canRetry(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodPatch, http.MethodPut:
+		return true
+	case http.MethodPost:
+		return c.RetryNonIdempotent
+	}
+	return false
+}
+
+// DefaultRetryableStatusCodes are the response statuses retried when
+// Client.RetryableStatusCodes is nil.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// isRetryableStatus reports whether a response status indicates a transient
+// failure worth retrying.
+func (c *Client) isRetryableStatus(code int) bool {
+	codes := c.RetryableStatusCodes
+	if codes == nil {
+		codes = DefaultRetryableStatusCodes
+	}
+	for _, rc := range codes {
+		if rc == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before retry attempt, preferring the server's
+// Retry-After header when resp carries one, capped by MaxRetryAfter.
+func (c *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if c.MaxRetryAfter > 0 && d > c.MaxRetryAfter {
+				d = c.MaxRetryAfter
+			}
+			return d
+		}
+	}
+	if c.RetryBackoff != nil {
+		return c.RetryBackoff(attempt)
+	}
+	return DefaultRetryBackoff(attempt)
+}
+
+// parseRetryAfter parses a Retry-After header given in delta-seconds or as
+// an HTTP-date, which is converted to the delay from now. A date in the
+// past means no delay.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,200 @@
+package regfishapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func noBackoff(int) time.Duration { return 0 }
+
+func TestRetryTransientStatus(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"response":{"id":7}}`))
+	})
+	client.MaxRetries = 3
+	client.RetryBackoff = noBackoff
+
+	rec, err := client.GetRecord(7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, rec.ID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	client.MaxRetries = 2
+	client.RetryBackoff = noBackoff
+
+	_, err := client.GetRecord(1)
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetrySkipsClientErrorsAndPOST(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	client.MaxRetries = 3
+	client.RetryBackoff = noBackoff
+
+	_, err := client.GetRecord(1)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, err = client.CreateRecord(Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	client.RetryNonIdempotent = true
+	_, err = client.CreateRecord(Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&calls))
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	d, ok := parseRetryAfter("2", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, d)
+
+	d, ok = parseRetryAfter("Tue, 05 Mar 2024 12:00:30 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, d)
+
+	d, ok = parseRetryAfter("Tue, 05 Mar 2024 11:00:00 GMT", now)
+	assert.True(t, ok)
+	assert.Zero(t, d)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("-1", now)
+	assert.False(t, ok)
+}
+
+func TestRetryAfterCap(t *testing.T) {
+	c := &Client{MaxRetryAfter: time.Second}
+	resp := &http.Response{Header: http.Header{"Retry-After": {"3600"}}}
+	assert.Equal(t, time.Second, c.backoff(1, resp))
+
+	c.MaxRetryAfter = 0
+	assert.Equal(t, time.Hour, c.backoff(1, resp))
+}
+
+func TestRetryTooManyRequestsPOST(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"response":{"id":1}}`))
+	}, WithRetry(2, time.Millisecond))
+
+	_, err := client.CreateRecord(Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff(100*time.Millisecond, time.Second)
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 5: time.Second, 10: time.Second} {
+		d := b(attempt)
+		assert.GreaterOrEqual(t, d, want/2)
+		assert.LessOrEqual(t, d, want)
+	}
+}
+
+func TestRetryNeverRetriesUnauthorized(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	client.MaxRetries = 3
+	client.RetryBackoff = noBackoff
+
+	_, err := client.GetRecord(1)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryableStatusCodes(t *testing.T) {
+	var calls int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"response":{"id":1}}`))
+	}
+
+	client := newTestClient(t, handler, WithRetry(2, time.Millisecond))
+	_, err := client.GetRecord(1)
+	assert.Error(t, err, "500 isn't retried by default")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	client = newTestClient(t, handler, WithRetry(2, time.Millisecond), WithRetryableStatusCodes(500, 503))
+	_, err = client.GetRecord(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	client = NewClient("key", WithRetryableStatusCodes())
+	assert.False(t, client.isRetryableStatus(http.StatusTooManyRequests))
+	assert.NotNil(t, client.Clone().RetryableStatusCodes)
+	assert.True(t, NewClient("key").isRetryableStatus(http.StatusGatewayTimeout))
+}
+
+func TestRetryResendsBody(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(b))
+		n := len(bodies)
+		mu.Unlock()
+		if n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"response":{"id":1}}`))
+	}, WithRetry(1, time.Millisecond), WithOnRequest(func(req *http.Request) {
+		if assert.NotNil(t, req.GetBody) {
+			b, _ := req.GetBody()
+			data, _ := io.ReadAll(b)
+			assert.Contains(t, string(data), "192.0.2.1")
+		}
+	}))
+
+	_, err := client.UpdateRecordById(1, NewARecord("www.example.com.", "192.0.2.1", 0))
+	assert.NoError(t, err)
+	if assert.Len(t, bodies, 2) {
+		assert.NotEmpty(t, bodies[0])
+		assert.Equal(t, bodies[0], bodies[1])
+	}
+}
@@ -0,0 +1,205 @@
+package regfishapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SetRecordData points the single record of domain matching name and
+// recordType at newData, keeping its TTL and other fields. If no record or
+// more than one record matches, nothing is changed and an error wrapping
+// ErrRecordNotFound or ErrMultipleRecords is returned. An empty name, "@"
+// and domain refer to the apex.
+func (c *Client) SetRecordData(domain, name, recordType, newData string) (Record, error) {
+	return c.SetRecordDataContext(context.Background(), domain, name, recordType, newData)
+}
+
+// SetRecordDataContext is like SetRecordData but uses ctx for the underlying requests.
+func (c *Client) SetRecordDataContext(ctx context.Context, domain, name, recordType, newData string) (Record, error) {
+	record, err := c.findOne(ctx, domain, apexName(name, domain), recordType)
+	if err != nil {
+		return Record{}, fmt.Errorf("set data: %w", err)
+	}
+	record.Data = newData
+	return c.UpdateRecordByIdContext(ctx, record.ID, record)
+}
+
+// SetRecordTTL changes the TTL of the record rrid, keeping its data and
+// other fields. The record is fetched first, so fields the caller doesn't
+// know about aren't reset. A ttl of 0 asks for the zone default.
+func (c *Client) SetRecordTTL(rrid, ttl int) (Record, error) {
+	return c.SetRecordTTLContext(context.Background(), rrid, ttl)
+}
+
+// SetRecordTTLContext is like SetRecordTTL but uses ctx for the underlying requests.
+func (c *Client) SetRecordTTLContext(ctx context.Context, rrid, ttl int) (Record, error) {
+	record, err := c.GetRecordContext(ctx, rrid)
+	if err != nil {
+		return Record{}, fmt.Errorf("set ttl: %w", err)
+	}
+	record.TTL = IntPtr(ttl)
+	return c.UpdateRecordByIdContext(ctx, rrid, record)
+}
+
+// SetRecordTTLByName is like SetRecordTTL for the single record of domain
+// matching name and recordType. If no record or more than one record
+// matches, nothing is changed and an error wrapping ErrRecordNotFound or
+// ErrMultipleRecords is returned. An empty name, "@" and domain refer to
+// the apex.
+func (c *Client) SetRecordTTLByName(domain, name, recordType string, ttl int) (Record, error) {
+	return c.SetRecordTTLByNameContext(context.Background(), domain, name, recordType, ttl)
+}
+
+// SetRecordTTLByNameContext is like SetRecordTTLByName but uses ctx for the underlying requests.
+func (c *Client) SetRecordTTLByNameContext(ctx context.Context, domain, name, recordType string, ttl int) (Record, error) {
+	record, err := c.findOne(ctx, domain, apexName(name, domain), recordType)
+	if err != nil {
+		return Record{}, fmt.Errorf("set ttl: %w", err)
+	}
+	record.TTL = IntPtr(ttl)
+	return c.UpdateRecordByIdContext(ctx, record.ID, record)
+}
+
+// SetZoneTTL changes the TTL of every record of domain to ttl, e.g. to
+// lower them before a planned migration and restore them afterwards.
+// Records of the exclude types, such as "SOA" and "NS", and records that
+// already have the TTL are left alone. Updates run concurrently, bounded by
+// Client.Concurrency, and a failed update doesn't stop the others.
+//
+// SetZoneTTL returns the updated records in zone order. Failed records are
+// reported in a *BatchError whose Index is the record's position in the
+// listing of domain. Once the context of SetZoneTTLContext is done, no
+// further updates are started and the error also matches ctx.Err().
+func (c *Client) SetZoneTTL(domain string, ttl int, exclude ...string) ([]Record, error) {
+	return c.SetZoneTTLContext(context.Background(), domain, ttl, exclude...)
+}
+
+// SetZoneTTLContext is like SetZoneTTL but uses ctx for the underlying requests.
+func (c *Client) SetZoneTTLContext(ctx context.Context, domain string, ttl int, exclude ...string) ([]Record, error) {
+	records, err := c.GetRecordsByDomainContext(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[string]bool, len(exclude))
+	for _, t := range exclude {
+		excluded[strings.ToUpper(t)] = true
+	}
+	var indices []int
+	for i, r := range records {
+		if excluded[strings.ToUpper(r.Type)] || (r.TTL != nil && *r.TTL == ttl) {
+			continue
+		}
+		indices = append(indices, i)
+	}
+
+	updated := make([]Record, len(records))
+	errs := make([]error, len(records))
+	started, stopErr := c.forEach(ctx, len(indices), func(n int) {
+		i := indices[n]
+		r := records[i]
+		r.TTL = IntPtr(ttl)
+		updated[i], errs[i] = c.UpdateRecordByIdContext(ctx, r.ID, r)
+	})
+
+	var result []Record
+	for _, i := range indices[:started] {
+		if errs[i] == nil {
+			result = append(result, updated[i])
+		}
+	}
+	return result, joinStopErr(stopErr, newBatchError(records, errs))
+}
+
+// updatableFields are the JSON names of the Record fields accepted by
+// UpdateRecordFields.
+var updatableFields = map[string]bool{
+	"name": true, "type": true, "data": true, "ttl": true,
+	"priority": true, "annotation": true, "tag": true, "flags": true,
+}
+
+// UpdateRecordFields changes only the given fields of the record rrid. The
+// current record is fetched, changes are applied to it and the merged
+// record is sent, so fields not in changes keep their values. Keys are the
+// JSON names of the Record fields, e.g. {"ttl": 300, "priority": 10}.
+// Unknown keys, the id, nil values and values of the wrong type are
+// rejected with a *ValidationError before anything is sent.
+func (c *Client) UpdateRecordFields(rrid int, changes map[string]interface{}) (Record, error) {
+	return c.UpdateRecordFieldsContext(context.Background(), rrid, changes)
+}
+
+// UpdateRecordFieldsContext is like UpdateRecordFields but uses ctx for the underlying requests.
+func (c *Client) UpdateRecordFieldsContext(ctx context.Context, rrid int, changes map[string]interface{}) (Record, error) {
+	for key, value := range changes {
+		if !updatableFields[key] {
+			return Record{}, &ValidationError{Field: key, Message: "is not an updatable field"}
+		}
+		if value == nil {
+			return Record{}, &ValidationError{Field: key, Message: "can't be set to nil"}
+		}
+	}
+	record, err := c.GetRecordContext(ctx, rrid)
+	if err != nil {
+		return Record{}, fmt.Errorf("update fields: %w", err)
+	}
+	if record, err = applyChanges(record, changes); err != nil {
+		return Record{}, err
+	}
+	return c.UpdateRecordByIdContext(ctx, rrid, record)
+}
+
+// applyChanges sets the fields of record named in changes by decoding each
+// change as JSON into it.
+func applyChanges(record Record, changes map[string]interface{}) (Record, error) {
+	for key, value := range changes {
+		b, err := json.Marshal(map[string]interface{}{key: value})
+		if err == nil {
+			err = json.Unmarshal(b, &record)
+		}
+		if err != nil {
+			return Record{}, &ValidationError{Field: key, Message: fmt.Sprintf("invalid value %v", value)}
+		}
+	}
+	return record, nil
+}
+
+// SetRecordComment sets the comment, stored in the Annotation field, of the
+// record rrid, keeping its other fields. An empty comment removes it by
+// sending an explicit null, which a plain update can't do.
+func (c *Client) SetRecordComment(rrid int, comment string) (Record, error) {
+	return c.SetRecordCommentContext(context.Background(), rrid, comment)
+}
+
+// SetRecordCommentContext is like SetRecordComment but uses ctx for the underlying requests.
+func (c *Client) SetRecordCommentContext(ctx context.Context, rrid int, comment string) (Record, error) {
+	record, err := c.GetRecordContext(ctx, rrid)
+	if err != nil {
+		return Record{}, fmt.Errorf("set comment: %w", err)
+	}
+	record.Annotation = nil
+	if record, err = c.prepareRecord(record); err != nil {
+		return Record{}, err
+	}
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		return Record{}, err
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(b, &body); err != nil {
+		return Record{}, err
+	}
+	body["annotation"] = json.RawMessage("null")
+	if comment != "" {
+		if body["annotation"], err = json.Marshal(comment); err != nil {
+			return Record{}, err
+		}
+	}
+	record.Annotation = nil
+	if comment != "" {
+		record.Annotation = &comment
+	}
+	return c.patchRecord(ctx, rrid, body, record)
+}
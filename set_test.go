@@ -0,0 +1,161 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRecordData(t *testing.T) {
+	var path string
+	var sent Record
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"response":[
+				{"id":2,"name":"www.example.com.","type":"A","data":"192.0.2.1","ttl":300,"annotation":"web"},
+				{"id":3,"name":"www.example.com.","type":"AAAA","data":"2001:db8::1"},
+				{"id":5,"name":"dup.example.com.","type":"A","data":"192.0.2.5"},
+				{"id":6,"name":"dup.example.com.","type":"A","data":"192.0.2.6"}
+			]}`))
+			return
+		}
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"response":{"id":2,"name":"www.example.com.","type":"A","data":"192.0.2.9","ttl":300}}`))
+	})
+
+	rec, err := client.SetRecordData("example.com", "www.example.com", "A", "192.0.2.9")
+	assert.NoError(t, err)
+	assert.Equal(t, "192.0.2.9", rec.Data)
+	assert.Equal(t, "/dns/rr/2", path)
+	assert.Equal(t, "192.0.2.9", sent.Data)
+	assert.Equal(t, IntPtr(300), sent.TTL)
+	if assert.NotNil(t, sent.Annotation) {
+		assert.Equal(t, "web", *sent.Annotation)
+	}
+
+	_, err = client.SetRecordData("example.com", "missing.example.com", "A", "192.0.2.9")
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+
+	_, err = client.SetRecordData("example.com", "dup.example.com", "A", "192.0.2.9")
+	assert.ErrorIs(t, err, ErrMultipleRecords)
+}
+
+func TestSetRecordTTL(t *testing.T) {
+	zone := newFakeZone(
+		Record{ID: 2, Name: "example.com.", Type: "MX", Data: "mail.example.com.", TTL: IntPtr(3600), Priority: IntPtr(10), Annotation: StringPtr("mail")},
+		Record{ID: 3, Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(3600)},
+	)
+	client := newTestClient(t, zone.ServeHTTP)
+
+	rec, err := client.SetRecordTTL(2, 300)
+	assert.NoError(t, err)
+	assert.Equal(t, 300, *rec.TTL)
+	assert.Equal(t, Record{ID: 2, Name: "example.com.", Type: "MX", Data: "mail.example.com.", TTL: IntPtr(300), Priority: IntPtr(10), Annotation: StringPtr("mail")}, zone.records[2])
+
+	rec, err = client.SetRecordTTLByName("example.com", "www.example.com", "A", 60)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rec.ID)
+	assert.Equal(t, 60, *zone.records[3].TTL)
+	assert.Equal(t, "192.0.2.1", zone.records[3].Data)
+
+	_, err = client.SetRecordTTLByName("example.com", "missing.example.com", "A", 60)
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestSetZoneTTL(t *testing.T) {
+	zone := newFakeZone(
+		Record{ID: 1, Name: "example.com.", Type: "NS", Data: "ns1.regfish.de.", TTL: IntPtr(86400)},
+		Record{ID: 2, Name: "example.com.", Type: "MX", Data: "mail.example.com.", TTL: IntPtr(3600), Priority: IntPtr(10)},
+		Record{ID: 3, Name: "www.example.com.", Type: "A", Data: "192.0.2.1"},
+		Record{ID: 4, Name: "ftp.example.com.", Type: "A", Data: "192.0.2.2", TTL: IntPtr(300)},
+		Record{ID: 5, Name: "old.example.com.", Type: "A", Data: "192.0.2.3", TTL: IntPtr(3600)},
+	)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch && r.URL.Path == "/dns/rr/5" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		zone.ServeHTTP(w, r)
+	}, WithConcurrency(2))
+
+	updated, err := client.SetZoneTTL("example.com", 300, "ns", "SOA")
+	var batchErr *BatchError
+	if assert.True(t, errors.As(err, &batchErr)) && assert.Len(t, batchErr.Errors, 1) {
+		assert.Equal(t, 4, batchErr.Errors[0].Index)
+		assert.Equal(t, 5, batchErr.Errors[0].Record.ID)
+	}
+	if assert.Len(t, updated, 2) {
+		assert.Equal(t, 2, updated[0].ID)
+		assert.Equal(t, 3, updated[1].ID)
+	}
+	assert.Equal(t, 86400, *zone.records[1].TTL)
+	assert.Equal(t, 300, *zone.records[2].TTL)
+	assert.Equal(t, 10, *zone.records[2].Priority)
+	assert.Equal(t, 300, *zone.records[3].TTL)
+	assert.Equal(t, 3600, *zone.records[5].TTL)
+}
+
+func TestUpdateRecordFields(t *testing.T) {
+	zone := newFakeZone(
+		Record{ID: 2, Name: "example.com.", Type: "MX", Data: "mail.example.com.", TTL: IntPtr(3600), Priority: IntPtr(10), Annotation: StringPtr("mail")},
+	)
+	client := newTestClient(t, zone.ServeHTTP)
+
+	rec, err := client.UpdateRecordFields(2, map[string]interface{}{"data": "mx.example.com.", "ttl": 300})
+	assert.NoError(t, err)
+	assert.Equal(t, "mx.example.com.", rec.Data)
+	assert.Equal(t, Record{ID: 2, Name: "example.com.", Type: "MX", Data: "mx.example.com.", TTL: IntPtr(300), Priority: IntPtr(10), Annotation: StringPtr("mail")}, zone.records[2])
+
+	for _, changes := range []map[string]interface{}{
+		{"bogus": 1},
+		{"id": 3},
+		{"ttl": "soon"},
+		{"priority": nil},
+	} {
+		_, err := client.UpdateRecordFields(2, changes)
+		var verr *ValidationError
+		assert.True(t, errors.As(err, &verr), "%v: got %v", changes, err)
+	}
+	assert.Equal(t, 300, *zone.records[2].TTL)
+}
+
+func TestSetRecordComment(t *testing.T) {
+	var sent map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"response":{"id":2,"name":"www.example.com.","type":"A","data":"192.0.2.1","ttl":300,"annotation":"old"}}`))
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"response":{"id":2}}`))
+	})
+
+	_, err := client.SetRecordComment(2, "managed by terraform, do not edit")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id": 2.0, "name": "www.example.com.", "type": "A", "data": "192.0.2.1", "ttl": 300.0,
+		"annotation": "managed by terraform, do not edit",
+	}, sent)
+
+	_, err = client.SetRecordComment(2, "")
+	assert.NoError(t, err)
+	if assert.Contains(t, sent, "annotation") {
+		assert.Nil(t, sent["annotation"])
+	}
+	assert.Equal(t, "192.0.2.1", sent["data"])
+}
+
+func TestSetRecordDataApex(t *testing.T) {
+	zone := newFakeZone(Record{ID: 1, Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	client := newTestClient(t, zone.ServeHTTP)
+
+	for _, name := range []string{"", "@"} {
+		_, err := client.SetRecordData("example.com", name, "A", "192.0.2.9")
+		assert.ErrorIs(t, err, ErrRecordNotFound, "%q", name)
+	}
+	assert.Equal(t, "192.0.2.1", zone.records[1].Data)
+}
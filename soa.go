@@ -0,0 +1,83 @@
+package regfishapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SOARecord is the parsed data of a zone's SOA record.
+type SOARecord struct {
+	// Record is the SOA record as returned by the API.
+	Record Record
+	// PrimaryNS is the primary name server of the zone.
+	PrimaryNS string
+	// Mailbox is the responsible person's mailbox in DNS form, e.g.
+	// "hostmaster.example.com." for hostmaster@example.com.
+	Mailbox string
+	Serial  uint32
+	// Refresh, Retry, Expire and Minimum are in seconds.
+	Refresh int
+	Retry   int
+	Expire  int
+	Minimum int
+}
+
+// GetSOA returns the SOA record of domain.
+func (c *Client) GetSOA(domain string) (SOARecord, error) {
+	return c.GetSOAContext(context.Background(), domain)
+}
+
+// GetSOAContext is like GetSOA but uses ctx for the underlying request.
+func (c *Client) GetSOAContext(ctx context.Context, domain string) (SOARecord, error) {
+	rec, err := c.findOne(ctx, domain, "", "SOA")
+	if err != nil {
+		return SOARecord{}, err
+	}
+	soa, err := ParseSOA(rec.Data)
+	if err != nil {
+		return SOARecord{}, fmt.Errorf("%s SOA: %w", domain, err)
+	}
+	soa.Record = rec
+	return soa, nil
+}
+
+// ParseSOA parses the data of an SOA record, "mname rname serial refresh
+// retry expire minimum". Record is left empty.
+func ParseSOA(data string) (SOARecord, error) {
+	fields := strings.Fields(data)
+	if len(fields) != 7 {
+		return SOARecord{}, fmt.Errorf("invalid SOA data %q: expected 7 fields, got %d", data, len(fields))
+	}
+	serial, err := strconv.ParseUint(fields[2], 10, 32)
+	if err != nil {
+		return SOARecord{}, fmt.Errorf("invalid SOA serial %q", fields[2])
+	}
+	soa := SOARecord{PrimaryNS: fields[0], Mailbox: fields[1], Serial: uint32(serial)}
+	for i, v := range []*int{&soa.Refresh, &soa.Retry, &soa.Expire, &soa.Minimum} {
+		if *v, err = strconv.Atoi(fields[3+i]); err != nil || *v < 0 {
+			return SOARecord{}, fmt.Errorf("invalid SOA timer %q", fields[3+i])
+		}
+	}
+	return soa, nil
+}
+
+// Data returns s in the form of an SOA record's Data field.
+func (s SOARecord) Data() string {
+	return fmt.Sprintf("%s %s %d %d %d %d %d", s.PrimaryNS, s.Mailbox, s.Serial, s.Refresh, s.Retry, s.Expire, s.Minimum)
+}
+
+// NextSerial returns the serial following serial in the YYYYMMDDnn
+// convention: the first serial of the day of now (in UTC) if serial is
+// older, and serial+1 otherwise. After 99 changes in a day, the serial runs
+// into the next day's numbers, which keeps it increasing.
+func NextSerial(serial uint32, now time.Time) uint32 {
+	now = now.UTC()
+	today := uint32(now.Year()*1000000 + int(now.Month())*10000 + now.Day()*100)
+	if serial < today {
+		return today
+	}
+	return serial + 1
+}
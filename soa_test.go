@@ -0,0 +1,56 @@
+package regfishapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSOA(t *testing.T) {
+	data := "ns1.regfish.de. hostmaster.example.com. 2024010203 3600 900 1209600 300"
+	soa, err := ParseSOA(data)
+	assert.NoError(t, err)
+	assert.Equal(t, SOARecord{
+		PrimaryNS: "ns1.regfish.de.",
+		Mailbox:   "hostmaster.example.com.",
+		Serial:    2024010203,
+		Refresh:   3600,
+		Retry:     900,
+		Expire:    1209600,
+		Minimum:   300,
+	}, soa)
+	assert.Equal(t, data, soa.Data())
+
+	_, err = ParseSOA("ns1.regfish.de. hostmaster.example.com. 1")
+	assert.ErrorContains(t, err, "expected 7 fields")
+	_, err = ParseSOA("ns1.regfish.de. hostmaster.example.com. x 3600 900 1209600 300")
+	assert.ErrorContains(t, err, "serial")
+	_, err = ParseSOA("ns1.regfish.de. hostmaster.example.com. 1 3600 -1 1209600 300")
+	assert.ErrorContains(t, err, "timer")
+}
+
+func TestGetSOA(t *testing.T) {
+	client := newTestClient(t, zoneHandler(`{"response":[
+		{"id":1,"name":"example.com.","type":"NS","data":"ns1.regfish.de."},
+		{"id":2,"name":"example.com.","type":"SOA","data":"ns1.regfish.de. hostmaster.example.com. 2024010203 3600 900 1209600 300"}
+	]}`))
+
+	soa, err := client.GetSOA("example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, soa.Record.ID)
+	assert.Equal(t, uint32(2024010203), soa.Serial)
+
+	client = newTestClient(t, zoneHandler(testZone))
+	_, err = client.GetSOA("example.com")
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestNextSerial(t *testing.T) {
+	now := time.Date(2024, 3, 5, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, uint32(2024030500), NextSerial(2024010203, now))
+	assert.Equal(t, uint32(2024030500), NextSerial(42, now))
+	assert.Equal(t, uint32(2024030508), NextSerial(2024030507, now))
+	assert.Equal(t, uint32(2024030600), NextSerial(2024030599, now))
+	assert.Equal(t, uint32(2024030500), NextSerial(0, now.In(time.FixedZone("UTC+2", 7200))))
+}
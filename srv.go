@@ -0,0 +1,92 @@
+package regfishapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SRV holds the components of an SRV record.
+type SRV struct {
+	// Service and Proto are the service and protocol labels without their
+	// leading underscore, e.g. "sip" and "tcp".
+	Service string
+	Proto   string
+	// Name is the domain the service is offered for.
+	Name     string
+	Priority int
+	Weight   int
+	Port     int
+	Target   string
+}
+
+// Record returns the SRV record described by s.
+func (s SRV) Record(ttl int) Record {
+	return NewSRVRecord(s.Service, s.Proto, s.Name, s.Priority, s.Weight, s.Port, s.Target, ttl)
+}
+
+// ParseSRV splits an SRV record into its components. The record name must
+// be of the form _service._proto.name and its data "weight port target".
+func ParseSRV(r Record) (SRV, error) {
+	if !strings.EqualFold(r.Type, "SRV") {
+		return SRV{}, fmt.Errorf("not an SRV record: %s", r.Type)
+	}
+
+	labels := strings.SplitN(r.Name, ".", 3)
+	if len(labels) < 3 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+		return SRV{}, fmt.Errorf("SRV name %q is not of the form _service._proto.name", r.Name)
+	}
+	srv := SRV{
+		Service: labels[0][1:],
+		Proto:   labels[1][1:],
+		Name:    labels[2],
+	}
+
+	if r.Priority == nil {
+		return SRV{}, fmt.Errorf("SRV record %s has no priority", r.Name)
+	}
+	srv.Priority = *r.Priority
+
+	fields := strings.Fields(r.Data)
+	if len(fields) != 3 {
+		return SRV{}, fmt.Errorf("SRV data %q is not of the form \"weight port target\"", r.Data)
+	}
+	var err error
+	if srv.Weight, err = parseUint16(fields[0]); err != nil {
+		return SRV{}, fmt.Errorf("SRV weight: %w", err)
+	}
+	if srv.Port, err = parseUint16(fields[1]); err != nil {
+		return SRV{}, fmt.Errorf("SRV port: %w", err)
+	}
+	srv.Target = fields[2]
+	return srv, nil
+}
+
+// validateSRV checks the data of an SRV record.
+func validateSRV(r Record) error {
+	fields := strings.Fields(r.Data)
+	if len(fields) != 3 {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("%q is not of the form \"weight port target\"", r.Data)}
+	}
+	if _, err := parseUint16(fields[0]); err != nil {
+		return &ValidationError{Field: "data", Message: "weight: " + err.Error()}
+	}
+	if _, err := parseUint16(fields[1]); err != nil {
+		return &ValidationError{Field: "data", Message: "port: " + err.Error()}
+	}
+	if fields[2] != "." {
+		if err := validateName(fields[2]); err != nil {
+			return &ValidationError{Field: "data", Message: fmt.Sprintf("target %q is not a valid name", fields[2])}
+		}
+	}
+	return nil
+}
+
+// parseUint16 parses s as an integer between 0 and 65535.
+func parseUint16(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 65535 {
+		return 0, fmt.Errorf("%q is not a number between 0 and 65535", s)
+	}
+	return n, nil
+}
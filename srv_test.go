@@ -0,0 +1,50 @@
+package regfishapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSRV(t *testing.T) {
+	rec := NewSRVRecord("xmpp-client", "tcp", "example.com.", 5, 10, 5222, "xmpp.example.com.", 300)
+
+	srv, err := ParseSRV(rec)
+	assert.NoError(t, err)
+	assert.Equal(t, SRV{
+		Service:  "xmpp-client",
+		Proto:    "tcp",
+		Name:     "example.com.",
+		Priority: 5,
+		Weight:   10,
+		Port:     5222,
+		Target:   "xmpp.example.com.",
+	}, srv)
+	assert.Equal(t, rec, srv.Record(300))
+}
+
+func TestParseSRVErrors(t *testing.T) {
+	prio := 1
+	for name, rec := range map[string]Record{
+		"wrong type":  {Name: "_sip._tcp.example.com.", Type: "A", Data: "192.0.2.1"},
+		"bad name":    {Name: "sip.example.com.", Type: "SRV", Data: "1 5060 sip.example.com.", Priority: &prio},
+		"no priority": {Name: "_sip._tcp.example.com.", Type: "SRV", Data: "1 5060 sip.example.com."},
+		"short data":  {Name: "_sip._tcp.example.com.", Type: "SRV", Data: "5060 sip.example.com.", Priority: &prio},
+		"bad port":    {Name: "_sip._tcp.example.com.", Type: "SRV", Data: "1 70000 sip.example.com.", Priority: &prio},
+	} {
+		_, err := ParseSRV(rec)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestValidateSRV(t *testing.T) {
+	prio := 1
+	assert.NoError(t, NewSRVRecord("sip", "udp", "example.com.", 1, 0, 5060, ".", 0).Validate())
+
+	err := Record{Name: "_sip._tcp.example.com.", Type: "SRV", Data: "1 x sip.example.com.", Priority: &prio}.Validate()
+	var verr *ValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, "data", verr.Field)
+	}
+}
@@ -0,0 +1,125 @@
+package regfishapi
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SSHFP key algorithms (RFC 4255, RFC 6594, RFC 7479).
+const (
+	SSHFPAlgorithmRSA     = 1
+	SSHFPAlgorithmDSA     = 2
+	SSHFPAlgorithmECDSA   = 3
+	SSHFPAlgorithmEd25519 = 4
+)
+
+// SSHFP fingerprint types.
+const (
+	SSHFPTypeSHA1   = 1
+	SSHFPTypeSHA256 = 2
+)
+
+// sshKeyAlgorithms maps SSH public key types to SSHFP algorithm numbers.
+var sshKeyAlgorithms = map[string]int{
+	"ssh-rsa":             SSHFPAlgorithmRSA,
+	"ssh-dss":             SSHFPAlgorithmDSA,
+	"ecdsa-sha2-nistp256": SSHFPAlgorithmECDSA,
+	"ecdsa-sha2-nistp384": SSHFPAlgorithmECDSA,
+	"ecdsa-sha2-nistp521": SSHFPAlgorithmECDSA,
+	"ssh-ed25519":         SSHFPAlgorithmEd25519,
+}
+
+// NewSSHFPRecord returns an SSHFP record publishing fingerprint, which is
+// hex-encoded in the record. Use Validate to check the parameters.
+func NewSSHFPRecord(name string, algorithm, fpType int, fingerprint []byte, ttl int) Record {
+	return Record{
+		Name: name,
+		Type: "SSHFP",
+		Data: fmt.Sprintf("%d %d %s", algorithm, fpType, hex.EncodeToString(fingerprint)),
+		TTL:  optionalTTL(ttl),
+	}
+}
+
+// NewSSHFPRecordFromKey returns an SSHFP record for a public key in
+// authorized_keys format, such as the contents of
+// /etc/ssh/ssh_host_ed25519_key.pub. The fingerprint is computed with
+// fpType.
+func NewSSHFPRecordFromKey(name string, authorizedKey []byte, fpType int, ttl int) (Record, error) {
+	fields := strings.Fields(string(authorizedKey))
+	if len(fields) < 2 {
+		return Record{}, errors.New("public key is not in authorized_keys format")
+	}
+	algorithm, ok := sshKeyAlgorithms[fields[0]]
+	if !ok {
+		return Record{}, fmt.Errorf("unsupported SSH key type %q", fields[0])
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to decode public key: %w", err)
+	}
+	if keyType, ok := sshKeyType(blob); !ok || keyType != fields[0] {
+		return Record{}, fmt.Errorf("public key does not match key type %q", fields[0])
+	}
+
+	var fingerprint []byte
+	switch fpType {
+	case SSHFPTypeSHA1:
+		sum := sha1.Sum(blob)
+		fingerprint = sum[:]
+	case SSHFPTypeSHA256:
+		sum := sha256.Sum256(blob)
+		fingerprint = sum[:]
+	default:
+		return Record{}, fmt.Errorf("invalid SSHFP fingerprint type %d", fpType)
+	}
+	return NewSSHFPRecord(name, algorithm, fpType, fingerprint, ttl), nil
+}
+
+// sshKeyType returns the key type encoded at the start of an SSH public
+// key blob.
+func sshKeyType(blob []byte) (string, bool) {
+	if len(blob) < 4 {
+		return "", false
+	}
+	n := binary.BigEndian.Uint32(blob)
+	if uint64(n) > uint64(len(blob)-4) {
+		return "", false
+	}
+	keyType := blob[4 : 4+n]
+	return string(keyType), !bytes.ContainsAny(keyType, " \t")
+}
+
+// validateSSHFP checks the data of an SSHFP record.
+func validateSSHFP(r Record) error {
+	fields := strings.Fields(r.Data)
+	if len(fields) != 3 {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("%q is not of the form \"algorithm type fingerprint\"", r.Data)}
+	}
+	switch fields[0] {
+	case "1", "2", "3", "4", "6":
+	default:
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("unknown SSHFP algorithm %q", fields[0])}
+	}
+
+	fpType, err := strconv.Atoi(fields[1])
+	want := map[int]int{SSHFPTypeSHA1: sha1.Size, SSHFPTypeSHA256: sha256.Size}[fpType]
+	if err != nil || want == 0 {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("unknown SSHFP fingerprint type %q", fields[1])}
+	}
+	fingerprint, err := hex.DecodeString(fields[2])
+	if err != nil {
+		return &ValidationError{Field: "data", Message: "fingerprint is not hex"}
+	}
+	if len(fingerprint) != want {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("fingerprint must be %d bytes for type %d, got %d", want, fpType, len(fingerprint))}
+	}
+	return nil
+}
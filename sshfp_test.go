@@ -0,0 +1,48 @@
+package regfishapi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sshString(s string) []byte {
+	b := make([]byte, 4, 4+len(s))
+	binary.BigEndian.PutUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
+
+func TestNewSSHFPRecord(t *testing.T) {
+	fp := sha256.Sum256([]byte("key"))
+	rec := NewSSHFPRecord("host.example.com.", SSHFPAlgorithmEd25519, SSHFPTypeSHA256, fp[:], 0)
+	assert.Equal(t, "SSHFP", rec.Type)
+	assert.Equal(t, "4 2 "+hex.EncodeToString(fp[:]), rec.Data)
+	assert.NoError(t, rec.Validate())
+
+	assert.Error(t, NewSSHFPRecord("host.example.com.", 5, 2, fp[:], 0).Validate())
+	assert.Error(t, NewSSHFPRecord("host.example.com.", 4, 3, fp[:], 0).Validate())
+	assert.Error(t, NewSSHFPRecord("host.example.com.", 4, 1, fp[:], 0).Validate())
+}
+
+func TestNewSSHFPRecordFromKey(t *testing.T) {
+	blob := append(sshString("ssh-ed25519"), sshString("0123456789abcdef0123456789abcdef")...)
+	key := "ssh-ed25519 " + base64.StdEncoding.EncodeToString(blob) + " root@host\n"
+
+	rec, err := NewSSHFPRecordFromKey("host.example.com.", []byte(key), SSHFPTypeSHA256, 3600)
+	assert.NoError(t, err)
+	sum := sha256.Sum256(blob)
+	assert.Equal(t, "4 2 "+hex.EncodeToString(sum[:]), rec.Data)
+	assert.Equal(t, IntPtr(3600), rec.TTL)
+	assert.NoError(t, rec.Validate())
+
+	_, err = NewSSHFPRecordFromKey("host.example.com.", []byte("ssh-rsa "+base64.StdEncoding.EncodeToString(blob)), SSHFPTypeSHA256, 0)
+	assert.Error(t, err, "key type mismatch")
+	_, err = NewSSHFPRecordFromKey("host.example.com.", []byte("not a key"), SSHFPTypeSHA256, 0)
+	assert.Error(t, err)
+	_, err = NewSSHFPRecordFromKey("host.example.com.", []byte(key), 3, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package regfishapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultProtectedTypes are the record types SyncZone never deletes unless
+// overridden with ProtectTypes.
+var DefaultProtectedTypes = []string{"NS", "SOA"}
+
+// SyncResult reports the changes made by SyncZone.
+type SyncResult struct {
+	Created []Record
+	Updated []Record
+	Deleted []Record
+	// Protected lists records that would have been deleted but were kept
+	// because their type is protected.
+	Protected []Record
+}
+
+// Changed reports whether the sync made any change.
+func (r SyncResult) Changed() bool {
+	return len(r.Created)+len(r.Updated)+len(r.Deleted) > 0
+}
+
+// SyncOption configures SyncZone.
+type SyncOption func(*syncConfig)
+
+type syncConfig struct {
+	protected map[string]bool
+}
+
+// ProtectTypes sets the record types SyncZone must not delete, replacing
+// DefaultProtectedTypes. Call it without arguments to allow deleting any
+// type.
+func ProtectTypes(types ...string) SyncOption {
+	return func(cfg *syncConfig) {
+		cfg.protected = map[string]bool{}
+		for _, t := range types {
+			cfg.protected[strings.ToUpper(t)] = true
+		}
+	}
+}
+
+// SyncZone converges the records of domain to desired: missing records are
+// created, records whose attributes differ are updated and records not in
+// desired are deleted, except for protected types (see ProtectTypes).
+// Records are matched as by Diff, so running SyncZone again with the same
+// input changes nothing.
+//
+// Failed changes don't stop the others. The result lists the changes that
+// were applied and the error joins all failures. With Client.DryRun set,
+// the result lists the planned changes and the error is ErrDryRun.
+//
+// Once the context of SyncZoneContext is done, no further changes are
+// started; the result lists the changes applied so far and the error
+// matches ctx.Err().
+func (c *Client) SyncZone(domain string, desired []Record, opts ...SyncOption) (SyncResult, error) {
+	return c.SyncZoneContext(context.Background(), domain, desired, opts...)
+}
+
+// SyncZoneContext is like SyncZone but uses ctx for the underlying requests.
+func (c *Client) SyncZoneContext(ctx context.Context, domain string, desired []Record, opts ...SyncOption) (SyncResult, error) {
+	cfg := syncConfig{}
+	ProtectTypes(DefaultProtectedTypes...)(&cfg)
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	actual, err := c.GetRecordsByDomainContext(ctx, domain)
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	toCreate, toUpdate, candidates := Diff(desired, actual)
+	var result SyncResult
+	var toDelete []Record
+	for _, r := range candidates {
+		if cfg.protected[strings.ToUpper(r.Type)] {
+			result.Protected = append(result.Protected, r)
+			continue
+		}
+		toDelete = append(toDelete, r)
+	}
+
+	if c.DryRun {
+		result.Created, result.Updated, result.Deleted = toCreate, toUpdate, toDelete
+		return result, ErrDryRun
+	}
+
+	var errs []error
+	created := make([]Record, len(toCreate))
+	createErrs := make([]error, len(toCreate))
+	started, stopErr := c.forEach(ctx, len(toCreate), func(i int) {
+		created[i], createErrs[i] = c.CreateRecordContext(ctx, toCreate[i])
+	})
+	for i, err := range createErrs[:started] {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("create %s %s: %w", toCreate[i].Name, toCreate[i].Type, err))
+			continue
+		}
+		result.Created = append(result.Created, created[i])
+	}
+	if stopErr != nil {
+		return result, joinStopErr(stopErr, errors.Join(errs...))
+	}
+
+	updated := make([]Record, len(toUpdate))
+	updateErrs := make([]error, len(toUpdate))
+	started, stopErr = c.forEach(ctx, len(toUpdate), func(i int) {
+		updated[i], updateErrs[i] = c.UpdateRecordByIdContext(ctx, toUpdate[i].ID, toUpdate[i])
+	})
+	for i, err := range updateErrs[:started] {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("update %s %s (id %d): %w", toUpdate[i].Name, toUpdate[i].Type, toUpdate[i].ID, err))
+			continue
+		}
+		result.Updated = append(result.Updated, updated[i])
+	}
+	if stopErr != nil {
+		return result, joinStopErr(stopErr, errors.Join(errs...))
+	}
+
+	deleteErrs := make([]error, len(toDelete))
+	started, stopErr = c.forEach(ctx, len(toDelete), func(i int) {
+		deleteErrs[i] = c.deleteRecord(ctx, toDelete[i].ID)
+	})
+	for i, err := range deleteErrs[:started] {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete %s %s (id %d): %w", toDelete[i].Name, toDelete[i].Type, toDelete[i].ID, err))
+			continue
+		}
+		result.Deleted = append(result.Deleted, toDelete[i])
+	}
+
+	return result, joinStopErr(stopErr, errors.Join(errs...))
+}
@@ -0,0 +1,146 @@
+package regfishapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeZone is an in-memory zone served over HTTP for sync tests.
+type fakeZone struct {
+	mu      sync.Mutex
+	nextID  int
+	records map[int]Record
+}
+
+func newFakeZone(records ...Record) *fakeZone {
+	z := &fakeZone{nextID: 100, records: map[int]Record{}}
+	for _, r := range records {
+		z.records[r.ID] = r
+	}
+	return z
+}
+
+func (z *fakeZone) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/dns/rr/"))
+	var rec Record
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/rr"):
+		list := []Record{}
+		for i := 0; i < z.nextID; i++ {
+			if rec, ok := z.records[i]; ok {
+				list = append(list, rec)
+			}
+		}
+		json.NewEncoder(w).Encode(map[string][]Record{"response": list})
+		return
+	case r.Method == http.MethodGet:
+		rec = z.records[id]
+	case r.Method == http.MethodPost:
+		json.NewDecoder(r.Body).Decode(&rec)
+		rec.ID = z.nextID
+		z.nextID++
+		z.records[rec.ID] = rec
+	case r.Method == http.MethodPatch:
+		json.NewDecoder(r.Body).Decode(&rec)
+		rec.ID = id
+		z.records[id] = rec
+	case r.Method == http.MethodDelete:
+		delete(z.records, id)
+		fmt.Fprint(w, `{"success":true}`)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]Record{"response": rec})
+}
+
+func TestSyncZone(t *testing.T) {
+	zone := newFakeZone(
+		Record{ID: 1, Name: "example.com.", Type: "NS", Data: "ns1.regfish.de."},
+		Record{ID: 2, Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(300)},
+		Record{ID: 3, Name: "old.example.com.", Type: "A", Data: "192.0.2.9", TTL: IntPtr(300)},
+	)
+	client := newTestClient(t, zone.ServeHTTP)
+
+	desired := []Record{
+		NewARecord("www.example.com.", "192.0.2.1", 60),
+		NewAAAARecord("www.example.com.", "2001:db8::1", 300),
+	}
+
+	result, err := client.SyncZone("example.com", desired)
+	assert.NoError(t, err)
+	assert.True(t, result.Changed())
+	assert.Len(t, result.Created, 1)
+	assert.Len(t, result.Updated, 1)
+	if assert.Len(t, result.Deleted, 1) {
+		assert.Equal(t, 3, result.Deleted[0].ID)
+	}
+	if assert.Len(t, result.Protected, 1) {
+		assert.Equal(t, "NS", result.Protected[0].Type)
+	}
+
+	// A second run has nothing left to do.
+	result, err = client.SyncZone("example.com", desired)
+	assert.NoError(t, err)
+	assert.False(t, result.Changed())
+
+	// Without protection the NS record goes too.
+	result, err = client.SyncZone("example.com", desired, ProtectTypes())
+	assert.NoError(t, err)
+	assert.Len(t, result.Deleted, 1)
+	assert.Len(t, zone.records, 2)
+}
+
+func TestSyncZoneIDN(t *testing.T) {
+	zone := newFakeZone(Record{ID: 1, Name: "www.xn--mller-kva.de.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(300)})
+	client := newTestClient(t, zone.ServeHTTP)
+
+	result, err := client.SyncZone("müller.de", []Record{NewARecord("www.müller.de.", "192.0.2.1", 300)})
+	assert.NoError(t, err)
+	assert.False(t, result.Changed())
+	assert.Len(t, zone.records, 1)
+}
+
+func TestSyncZoneDryRun(t *testing.T) {
+	zone := newFakeZone(Record{ID: 1, Name: "old.example.com.", Type: "A", Data: "192.0.2.9"})
+	client := newTestClient(t, zone.ServeHTTP, WithDryRun())
+
+	result, err := client.SyncZone("example.com", []Record{NewARecord("new.example.com.", "192.0.2.1", 300)})
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Len(t, result.Created, 1)
+	assert.Len(t, result.Deleted, 1)
+	assert.Len(t, zone.records, 1)
+}
+
+func TestSyncZoneStopsWhenContextDone(t *testing.T) {
+	zone := newFakeZone(Record{ID: 1, Name: "old.example.com.", Type: "A", Data: "192.0.2.9"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := newTestClient(t, zone.ServeHTTP, WithConcurrency(1), WithOnResponse(func(resp *http.Response, elapsed time.Duration) {
+		if resp.Request.Method == http.MethodPost {
+			cancel()
+		}
+	}))
+
+	desired := []Record{
+		NewARecord("a.example.com.", "192.0.2.1", 0),
+		NewARecord("b.example.com.", "192.0.2.2", 0),
+	}
+	result, err := client.SyncZoneContext(ctx, "example.com", desired)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, result.Created, 1)
+	assert.Empty(t, result.Deleted)
+	zone.mu.Lock()
+	defer zone.mu.Unlock()
+	assert.Len(t, zone.records, 2, "the delete phase must not run")
+}
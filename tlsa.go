@@ -0,0 +1,146 @@
+package regfishapi
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TLSA certificate usages (RFC 6698, RFC 7218).
+const (
+	TLSAUsagePKIXTA = 0
+	TLSAUsagePKIXEE = 1
+	TLSAUsageDANETA = 2
+	TLSAUsageDANEEE = 3
+)
+
+// TLSA selectors.
+const (
+	TLSASelectorCert = 0
+	TLSASelectorSPKI = 1
+)
+
+// TLSA matching types.
+const (
+	TLSAMatchingFull   = 0
+	TLSAMatchingSHA256 = 1
+	TLSAMatchingSHA512 = 2
+)
+
+// TLSAName returns the owner name of a TLSA record for a service on port
+// and proto of host, e.g. TLSAName(443, "tcp", "www.example.com.") is
+// "_443._tcp.www.example.com.".
+func TLSAName(port int, proto, host string) string {
+	return fmt.Sprintf("_%d._%s.%s", port, strings.TrimPrefix(proto, "_"), host)
+}
+
+// NewTLSARecord returns a TLSA record with the given usage, selector,
+// matching type and certificate association data, which is hex-encoded in
+// the record. Use Validate to check the parameters.
+func NewTLSARecord(name string, usage, selector, matchingType int, certData []byte, ttl int) Record {
+	return Record{
+		Name: name,
+		Type: "TLSA",
+		Data: fmt.Sprintf("%d %d %d %s", usage, selector, matchingType, hex.EncodeToString(certData)),
+		TTL:  optionalTTL(ttl),
+	}
+}
+
+// NewTLSARecordFromCert returns a TLSA record whose association data is
+// computed from cert according to selector and matchingType.
+func NewTLSARecordFromCert(name string, usage, selector, matchingType int, cert *x509.Certificate, ttl int) (Record, error) {
+	data, err := TLSAAssociationData(cert, selector, matchingType)
+	if err != nil {
+		return Record{}, err
+	}
+	rec := NewTLSARecord(name, usage, selector, matchingType, data, ttl)
+	return rec, validateTLSA(rec)
+}
+
+// NewTLSARecordFromPEM is like NewTLSARecordFromCert for the first
+// certificate in PEM-encoded data, such as the contents of a .pem file.
+func NewTLSARecordFromPEM(name string, usage, selector, matchingType int, pemData []byte, ttl int) (Record, error) {
+	for {
+		var block *pem.Block
+		block, pemData = pem.Decode(pemData)
+		if block == nil {
+			return Record{}, errors.New("no certificate found in PEM data")
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return Record{}, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		return NewTLSARecordFromCert(name, usage, selector, matchingType, cert, ttl)
+	}
+}
+
+// TLSAAssociationData computes the certificate association data of cert
+// for the given selector and matching type.
+func TLSAAssociationData(cert *x509.Certificate, selector, matchingType int) ([]byte, error) {
+	var data []byte
+	switch selector {
+	case TLSASelectorCert:
+		data = cert.Raw
+	case TLSASelectorSPKI:
+		data = cert.RawSubjectPublicKeyInfo
+	default:
+		return nil, fmt.Errorf("invalid TLSA selector %d", selector)
+	}
+
+	switch matchingType {
+	case TLSAMatchingFull:
+		return data, nil
+	case TLSAMatchingSHA256:
+		sum := sha256.Sum256(data)
+		return sum[:], nil
+	case TLSAMatchingSHA512:
+		sum := sha512.Sum512(data)
+		return sum[:], nil
+	}
+	return nil, fmt.Errorf("invalid TLSA matching type %d", matchingType)
+}
+
+// validateTLSA checks the data of a TLSA record.
+func validateTLSA(r Record) error {
+	fields := strings.Fields(r.Data)
+	if len(fields) != 4 {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("%q is not of the form \"usage selector matching-type data\"", r.Data)}
+	}
+	if err := checkEnum("usage", fields[0], 3); err != nil {
+		return err
+	}
+	if err := checkEnum("selector", fields[1], 1); err != nil {
+		return err
+	}
+	if err := checkEnum("matching type", fields[2], 2); err != nil {
+		return err
+	}
+
+	assoc, err := hex.DecodeString(fields[3])
+	if err != nil || len(assoc) == 0 {
+		return &ValidationError{Field: "data", Message: "certificate association data is not hex"}
+	}
+	want := map[string]int{"1": sha256.Size, "2": sha512.Size}[fields[2]]
+	if want != 0 && len(assoc) != want {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("digest must be %d bytes for matching type %s, got %d", want, fields[2], len(assoc))}
+	}
+	return nil
+}
+
+// checkEnum checks that the data field named what is an integer in [0, max].
+func checkEnum(what, s string, max int) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > max {
+		return &ValidationError{Field: "data", Message: fmt.Sprintf("%s must be between 0 and %d, got %q", what, max, s)}
+	}
+	return nil
+}
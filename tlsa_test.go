@@ -0,0 +1,73 @@
+package regfishapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCertificate(t *testing.T) (*x509.Certificate, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "www.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestNewTLSARecord(t *testing.T) {
+	name := TLSAName(443, "tcp", "www.example.com.")
+	assert.Equal(t, "_443._tcp.www.example.com.", name)
+
+	digest := sha256.Sum256([]byte("spki"))
+	rec := NewTLSARecord(name, TLSAUsageDANEEE, TLSASelectorSPKI, TLSAMatchingSHA256, digest[:], 300)
+	assert.Equal(t, "TLSA", rec.Type)
+	assert.Equal(t, "3 1 1 "+hex.EncodeToString(digest[:]), rec.Data)
+	assert.NoError(t, rec.Validate())
+
+	assert.Error(t, NewTLSARecord(name, 4, 1, 1, digest[:], 0).Validate())
+	assert.Error(t, NewTLSARecord(name, 3, 2, 1, digest[:], 0).Validate())
+	assert.Error(t, NewTLSARecord(name, 3, 1, 3, digest[:], 0).Validate())
+	assert.Error(t, NewTLSARecord(name, 3, 1, 2, digest[:], 0).Validate())
+}
+
+func TestNewTLSARecordFromPEM(t *testing.T) {
+	cert, pemData := testCertificate(t)
+
+	rec, err := NewTLSARecordFromPEM("_443._tcp.www.example.com.", TLSAUsageDANEEE, TLSASelectorSPKI, TLSAMatchingSHA256, pemData, 0)
+	assert.NoError(t, err)
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	assert.True(t, strings.HasSuffix(rec.Data, hex.EncodeToString(sum[:])))
+
+	rec, err = NewTLSARecordFromCert("_443._tcp.www.example.com.", TLSAUsageDANETA, TLSASelectorCert, TLSAMatchingFull, cert, 0)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(rec.Data, hex.EncodeToString(cert.Raw)))
+
+	_, err = NewTLSARecordFromPEM("_443._tcp.www.example.com.", 3, 1, 1, []byte("garbage"), 0)
+	assert.Error(t, err)
+}
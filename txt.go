@@ -0,0 +1,84 @@
+package regfishapi
+
+import (
+	"strings"
+)
+
+// maxTXTChunk is the longest character-string a TXT record may contain
+// (RFC 1035, section 3.3).
+const maxTXTChunk = 255
+
+// NewTXTRecord returns a TXT record for value. Values longer than 255 bytes,
+// such as DKIM keys, are split into several quoted character-strings
+// ("part1" "part2"), which resolvers concatenate again.
+func NewTXTRecord(name, value string, ttl int) Record {
+	return Record{
+		Name: name,
+		Type: "TXT",
+		Data: formatTXT(value),
+		TTL:  optionalTTL(ttl),
+	}
+}
+
+// TXTValue returns the text of a TXT record with its character-strings
+// unquoted and joined, i.e. the inverse of NewTXTRecord. Data that isn't
+// quoted is returned as is.
+func (r Record) TXTValue() string {
+	return parseTXT(r.Data)
+}
+
+// formatTXT splits value into quoted chunks of at most maxTXTChunk bytes.
+func formatTXT(value string) string {
+	if value == "" {
+		return `""`
+	}
+	var chunks []string
+	for len(value) > 0 {
+		n := maxTXTChunk
+		if n > len(value) {
+			n = len(value)
+		}
+		chunks = append(chunks, quoteTXT(value[:n]))
+		value = value[n:]
+	}
+	return strings.Join(chunks, " ")
+}
+
+// quoteTXT quotes s as a zonefile character-string.
+func quoteTXT(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// parseTXT concatenates the quoted character-strings in data. Text outside
+// quotes other than whitespace is kept verbatim.
+func parseTXT(data string) string {
+	if !strings.Contains(data, `"`) {
+		return data
+	}
+	var b strings.Builder
+	inQuotes := false
+	for i := 0; i < len(data); i++ {
+		ch := data[i]
+		switch {
+		case ch == '\\' && inQuotes && i+1 < len(data):
+			i++
+			b.WriteByte(data[i])
+		case ch == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && (ch == ' ' || ch == '\t'):
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,38 @@
+package regfishapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTXTRecord(t *testing.T) {
+	rec := NewTXTRecord("example.com.", "v=spf1 -all", 300)
+	assert.Equal(t, "TXT", rec.Type)
+	assert.Equal(t, `"v=spf1 -all"`, rec.Data)
+	assert.Equal(t, IntPtr(300), rec.TTL)
+	assert.Equal(t, "v=spf1 -all", rec.TXTValue())
+}
+
+func TestNewTXTRecordLong(t *testing.T) {
+	value := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 400)
+	rec := NewTXTRecord("sel._domainkey.example.com.", value, 0)
+
+	parts := strings.Split(rec.Data, `" "`)
+	if assert.Len(t, parts, 2) {
+		assert.Len(t, strings.TrimPrefix(parts[0], `"`), 255)
+	}
+	assert.Equal(t, value, rec.TXTValue())
+}
+
+func TestTXTEscaping(t *testing.T) {
+	value := `say "hi" \o/`
+	rec := NewTXTRecord("example.com.", value, 0)
+	assert.Equal(t, `"say \"hi\" \\o/"`, rec.Data)
+	assert.Equal(t, value, rec.TXTValue())
+}
+
+func TestTXTValueUnquoted(t *testing.T) {
+	assert.Equal(t, "plain text", Record{Type: "TXT", Data: "plain text"}.TXTValue())
+}
@@ -0,0 +1,34 @@
+package regfishapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// UpsertRecord updates the record of domain with the same name and type as
+// record, or creates record if there is none. If more than one record
+// matches, nothing is changed and an error wrapping ErrMultipleRecords is
+// returned. An empty record name, "@" and domain refer to the apex, which
+// is sent as the fully qualified domain.
+func (c *Client) UpsertRecord(domain string, record Record) (Record, error) {
+	return c.UpsertRecordContext(context.Background(), domain, record)
+}
+
+// UpsertRecordContext is like UpsertRecord but uses ctx for the underlying requests.
+func (c *Client) UpsertRecordContext(ctx context.Context, domain string, record Record) (Record, error) {
+	record.Name = apexName(record.Name, domain)
+	existing, err := c.FindRecordsContext(ctx, domain, record.Name, record.Type)
+	if err != nil {
+		return Record{}, err
+	}
+
+	switch len(existing) {
+	case 0:
+		return c.CreateRecordContext(ctx, record)
+	case 1:
+		record.ID = existing[0].ID
+		return c.UpdateRecordByIdContext(ctx, existing[0].ID, record)
+	default:
+		return Record{}, fmt.Errorf("upsert %s %s: %w (%d found)", record.Name, record.Type, ErrMultipleRecords, len(existing))
+	}
+}
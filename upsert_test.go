@@ -0,0 +1,49 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertRecord(t *testing.T) {
+	var method, path string
+	var sent Record
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(testZone))
+			return
+		}
+		method, path = r.Method, r.URL.Path
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"response":{"id":9}}`))
+	})
+
+	_, err := client.UpsertRecord("example.com", Record{Name: "mail.example.com.", Type: "A", Data: "192.0.2.9"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPatch, method)
+	assert.Equal(t, "/dns/rr/4", path)
+	assert.Equal(t, 4, sent.ID)
+
+	_, err = client.UpsertRecord("example.com", Record{Name: "new.example.com.", Type: "A", Data: "192.0.2.9"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/dns/rr", path)
+}
+
+func TestUpsertRecordAmbiguous(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		w.Write([]byte(`{"response":[
+			{"id":1,"name":"web.example.com.","type":"A","data":"192.0.2.1"},
+			{"id":2,"name":"web.example.com.","type":"A","data":"192.0.2.2"}
+		]}`))
+	})
+
+	_, err := client.UpsertRecord("example.com", Record{Name: "web.example.com.", Type: "A", Data: "192.0.2.3"})
+	assert.ErrorIs(t, err, ErrMultipleRecords)
+}
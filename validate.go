@@ -0,0 +1,238 @@
+package regfishapi
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// TTL bounds enforced by Record.Validate. An unset TTL and an explicit TTL
+// of zero, which asks for the zone default, are always accepted.
+const (
+	MinTTL = 60
+	MaxTTL = 604800
+)
+
+// ValidationError describes a Record field that failed client-side
+// validation or was rejected by the API, see APIError.Errors.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid record %s: %s", e.Field, e.Message)
+}
+
+// Validate checks that r is well-formed before it is sent to the API. It
+// returns a *ValidationError describing the first problem found.
+func (r Record) Validate() error {
+	if err := validateName(r.Name); err != nil {
+		return err
+	}
+	if r.Type == "" {
+		return &ValidationError{Field: "type", Message: "must not be empty"}
+	}
+	if !RecordType(r.Type).IsValid() {
+		return &ValidationError{Field: "type", Message: fmt.Sprintf("unknown record type %q", r.Type)}
+	}
+	if strings.TrimSpace(r.Data) == "" {
+		return &ValidationError{Field: "data", Message: "must not be empty"}
+	}
+	if r.TTL != nil && *r.TTL != 0 && (*r.TTL < MinTTL || *r.TTL > MaxTTL) {
+		return &ValidationError{Field: "ttl", Message: fmt.Sprintf("must be between %d and %d, got %d", MinTTL, MaxTTL, *r.TTL)}
+	}
+	switch strings.ToUpper(r.Type) {
+	case "A":
+		addr, err := netip.ParseAddr(strings.TrimSpace(r.Data))
+		if err != nil || !addr.Is4() {
+			return &ValidationError{Field: "data", Message: fmt.Sprintf("%q is not an IPv4 address", r.Data)}
+		}
+	case "AAAA":
+		addr, err := netip.ParseAddr(strings.TrimSpace(r.Data))
+		if err != nil || !addr.Is6() || addr.Zone() != "" {
+			return &ValidationError{Field: "data", Message: fmt.Sprintf("%q is not an IPv6 address", r.Data)}
+		}
+	case "CAA":
+		return validateCAA(r)
+	case "DNSKEY":
+		return validateDNSKEY(r)
+	case "DS":
+		return validateDS(r)
+	case "MX":
+		return validatePriority(r)
+	case "PTR":
+		if err := validateName(r.Data); err != nil {
+			return &ValidationError{Field: "data", Message: fmt.Sprintf("target %q is not a valid name", r.Data)}
+		}
+	case "SSHFP":
+		return validateSSHFP(r)
+	case "TLSA":
+		return validateTLSA(r)
+	case "SRV":
+		if err := validatePriority(r); err != nil {
+			return err
+		}
+		return validateSRV(r)
+	}
+	return nil
+}
+
+// ZoneError describes a structural problem found by ValidateZone that
+// involves several records, such as a CNAME sharing its name with other
+// records.
+type ZoneError struct {
+	// Name is the owner name the problem concerns. It is empty for
+	// problems with the zone as a whole.
+	Name    string
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ZoneError) Error() string {
+	if e.Name == "" {
+		return "invalid zone: " + e.Message
+	}
+	return fmt.Sprintf("invalid zone: %s: %s", e.Name, e.Message)
+}
+
+// ValidateZone checks records, the complete contents of a zone, for
+// mistakes the API would reject or that break resolution, e.g. before
+// passing them to SyncZone. It reports every record failing Validate as a
+// *RecordError and these structural problems as a *ZoneError:
+//
+//   - a CNAME sharing its name with other records, including another CNAME
+//   - more than one SOA record
+//   - no NS records
+//   - an MX, NS or SRV record whose target is the name of a CNAME
+//
+// Names are compared case-insensitively with or without a trailing dot.
+// ValidateZone returns nil if it finds no problem.
+func ValidateZone(records []Record) []error {
+	var errs []error
+	var names []string
+	types := make(map[string][]string)
+	soas, nss := 0, 0
+	for i, r := range records {
+		if err := r.Validate(); err != nil {
+			errs = append(errs, &RecordError{Index: i, Record: r, Err: err})
+		}
+		name := nameKey(r.Name)
+		if _, ok := types[name]; !ok {
+			names = append(names, name)
+		}
+		types[name] = append(types[name], strings.ToUpper(r.Type))
+		switch strings.ToUpper(r.Type) {
+		case "SOA":
+			soas++
+		case "NS":
+			nss++
+		}
+	}
+
+	cnames := make(map[string]bool)
+	for _, name := range names {
+		var cnameCount int
+		var others []string
+		for _, t := range types[name] {
+			if t == "CNAME" {
+				cnameCount++
+			} else {
+				others = append(others, t)
+			}
+		}
+		if cnameCount == 0 {
+			continue
+		}
+		cnames[name] = true
+		switch {
+		case cnameCount > 1:
+			errs = append(errs, &ZoneError{Name: name, Message: fmt.Sprintf("has %d CNAME records", cnameCount)})
+		case len(others) > 0:
+			sort.Strings(others)
+			errs = append(errs, &ZoneError{Name: name, Message: fmt.Sprintf("CNAME must not coexist with other records (%s)", strings.Join(others, ", "))})
+		}
+	}
+
+	if soas > 1 {
+		errs = append(errs, &ZoneError{Message: fmt.Sprintf("has %d SOA records", soas)})
+	}
+	if nss == 0 {
+		errs = append(errs, &ZoneError{Message: "has no NS records"})
+	}
+
+	for _, r := range records {
+		target := recordTarget(r)
+		if target != "" && cnames[nameKey(target)] {
+			errs = append(errs, &ZoneError{Name: nameKey(r.Name), Message: fmt.Sprintf("%s target %s is a CNAME", strings.ToUpper(r.Type), target)})
+		}
+	}
+	return errs
+}
+
+// recordTarget returns the host name an MX, NS or SRV record points to, or
+// "" for other records.
+func recordTarget(r Record) string {
+	switch strings.ToUpper(r.Type) {
+	case "MX", "NS":
+		return strings.TrimSpace(r.Data)
+	case "SRV":
+		if fields := strings.Fields(r.Data); len(fields) == 3 {
+			return fields[2]
+		}
+	}
+	return ""
+}
+
+// validatePriority checks the Priority field required by MX and SRV records.
+func validatePriority(r Record) error {
+	if r.Priority == nil {
+		return &ValidationError{Field: "priority", Message: fmt.Sprintf("is required for %s records", strings.ToUpper(r.Type))}
+	}
+	if *r.Priority < 0 || *r.Priority > 65535 {
+		return &ValidationError{Field: "priority", Message: fmt.Sprintf("must be between 0 and 65535, got %d", *r.Priority)}
+	}
+	return nil
+}
+
+// validateName checks the length and label structure of a DNS name.
+func validateName(name string) error {
+	if name == "" {
+		return &ValidationError{Field: "name", Message: "must not be empty"}
+	}
+	trimmed := strings.TrimSuffix(name, ".")
+	if len(trimmed) > 253 {
+		return &ValidationError{Field: "name", Message: "must not be longer than 253 characters"}
+	}
+	for _, label := range strings.Split(trimmed, ".") {
+		if label == "" {
+			return &ValidationError{Field: "name", Message: fmt.Sprintf("%q contains an empty label", name)}
+		}
+		if len(label) > 63 {
+			return &ValidationError{Field: "name", Message: fmt.Sprintf("label %q is longer than 63 characters", label)}
+		}
+		if strings.ContainsAny(label, " \t\r\n") {
+			return &ValidationError{Field: "name", Message: fmt.Sprintf("%q contains whitespace", name)}
+		}
+	}
+	return nil
+}
+
+// normalizeIP returns the canonical form of the address in the Data field
+// of A and AAAA records, e.g. "2001:db8::1" for "2001:0db8:0:0:0:0:0:1".
+// Data that doesn't parse is returned unchanged for Validate to report.
+func normalizeIP(recordType, data string) string {
+	addr, err := netip.ParseAddr(strings.TrimSpace(data))
+	if err != nil {
+		return data
+	}
+	switch {
+	case strings.EqualFold(recordType, "A") && addr.Is4():
+		return addr.String()
+	case strings.EqualFold(recordType, "AAAA") && addr.Is6() && !addr.Is4In6():
+		return addr.String()
+	}
+	return data
+}
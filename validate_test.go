@@ -0,0 +1,139 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordValidate(t *testing.T) {
+	prio := 10
+	tests := []struct {
+		name   string
+		record Record
+		field  string
+	}{
+		{"valid", Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(300)}, ""},
+		{"valid mx", Record{Name: "example.com.", Type: "mx", Data: "mail.example.com.", Priority: &prio}, ""},
+		{"empty name", Record{Type: "A", Data: "192.0.2.1"}, "name"},
+		{"empty label", Record{Name: "www..example.com.", Type: "A", Data: "192.0.2.1"}, "name"},
+		{"long label", Record{Name: strings.Repeat("a", 64) + ".example.com.", Type: "A", Data: "192.0.2.1"}, "name"},
+		{"empty type", Record{Name: "www.example.com.", Data: "192.0.2.1"}, "type"},
+		{"unknown type", Record{Name: "www.example.com.", Type: "BOGUS", Data: "x"}, "type"},
+		{"empty data", Record{Name: "www.example.com.", Type: "A"}, "data"},
+		{"ttl too low", Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(5)}, "ttl"},
+		{"a with ipv6", Record{Name: "www.example.com.", Type: "A", Data: "2001:db8::1"}, "data"},
+		{"a with hostname", Record{Name: "www.example.com.", Type: "A", Data: "web.example.com."}, "data"},
+		{"aaaa with ipv4", Record{Name: "www.example.com.", Type: "AAAA", Data: "192.0.2.1"}, "data"},
+		{"valid aaaa", Record{Name: "www.example.com.", Type: "AAAA", Data: "2001:db8::1"}, ""},
+		{"mx without priority", Record{Name: "example.com.", Type: "MX", Data: "mail.example.com."}, "priority"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.record.Validate()
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var verr *ValidationError
+			if assert.True(t, errors.As(err, &verr), "got %v", err) {
+				assert.Equal(t, tt.field, verr.Field)
+			}
+		})
+	}
+}
+
+func TestCreateRecordValidates(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid record was sent to the server")
+	}, WithValidation())
+
+	_, err := client.CreateRecord(Record{Name: "www.example.com.", Data: "192.0.2.1"})
+	var verr *ValidationError
+	assert.True(t, errors.As(err, &verr))
+}
+
+func TestNormalizeIP(t *testing.T) {
+	assert.Equal(t, "2001:db8::1", normalizeIP("AAAA", "2001:0DB8:0000:0000:0000:0000:0000:0001"))
+	assert.Equal(t, "192.0.2.1", normalizeIP("a", " 192.0.2.1 "))
+	assert.Equal(t, "::ffff:192.0.2.1", normalizeIP("AAAA", "::ffff:192.0.2.1"))
+	assert.Equal(t, "not-an-ip", normalizeIP("A", "not-an-ip"))
+	assert.Equal(t, "192.0.2.1", normalizeIP("TXT", "192.0.2.1"))
+}
+
+func TestCreateRecordNormalizesIP(t *testing.T) {
+	var sent Record
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"response":{}}`))
+	})
+
+	_, err := client.CreateRecord(NewAAAARecord("www.example.com.", "2001:db8:0:0::1", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::1", sent.Data)
+}
+
+func TestQualifyNames(t *testing.T) {
+	var sent Record
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"response":{}}`))
+	}
+
+	client := newTestClient(t, handler)
+	_, err := client.CreateRecord(NewARecord("www.example.com", "192.0.2.1", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "www.example.com", sent.Name)
+
+	client = newTestClient(t, handler, WithQualifiedNames())
+	_, err = client.CreateRecord(NewARecord("www.example.com", "192.0.2.1", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "www.example.com.", sent.Name)
+
+	_, err = client.UpdateRecordById(1, NewARecord("www.example.com.", "192.0.2.1", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "www.example.com.", sent.Name)
+}
+
+func TestValidateZone(t *testing.T) {
+	ns := Record{Name: "example.com.", Type: "NS", Data: "ns1.regfish.de."}
+	assert.Nil(t, ValidateZone([]Record{
+		ns,
+		NewARecord("www.example.com.", "192.0.2.1", 300),
+		NewCNAMERecord("ftp.example.com.", "www.example.com.", 300),
+	}))
+
+	errs := ValidateZone([]Record{
+		ns,
+		NewCNAMERecord("WWW.example.com", "web.example.net.", 300),
+		NewARecord("www.example.com.", "192.0.2.1", 300),
+		NewTXTRecord("www.example.com.", "hello", 300),
+		NewCNAMERecord("alias.example.com.", "a.example.net.", 300),
+		NewCNAMERecord("alias.example.com.", "b.example.net.", 300),
+		NewMXRecord("example.com.", 10, "alias.example.com.", 300),
+		{Name: "example.com.", Type: "SOA", Data: "ns1.regfish.de. hostmaster.regfish.de. 1 2 3 4 5"},
+		{Name: "example.com.", Type: "SOA", Data: "ns1.regfish.de. hostmaster.regfish.de. 1 2 3 4 5"},
+		NewARecord("bad.example.com.", "not-an-ip", 300),
+	})
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	assert.Equal(t, []string{
+		`record 9 (bad.example.com. A): invalid record data: "not-an-ip" is not an IPv4 address`,
+		"invalid zone: www.example.com.: CNAME must not coexist with other records (A, TXT)",
+		"invalid zone: alias.example.com.: has 2 CNAME records",
+		"invalid zone: has 2 SOA records",
+		"invalid zone: example.com.: MX target alias.example.com. is a CNAME",
+	}, msgs)
+
+	var zerr *ZoneError
+	errs = ValidateZone(nil)
+	if assert.Len(t, errs, 1) && assert.True(t, errors.As(errs[0], &zerr)) {
+		assert.Equal(t, "has no NS records", zerr.Message)
+	}
+}
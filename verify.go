@@ -0,0 +1,78 @@
+package regfishapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNotPropagated is returned by updates made with VerifyTimeout set if
+// the change didn't show up on the authoritative name servers in time. The
+// update itself succeeded.
+var ErrNotPropagated = errors.New("change not visible on authoritative name servers")
+
+// DefaultVerifyInterval is how often the authoritative name servers are
+// polled while an update is verified.
+const DefaultVerifyInterval = time.Second
+
+// verifyApplied waits until the authoritative name servers answer with the
+// data of r, as configured by VerifyTimeout and VerifyNameservers. Records
+// of types WaitForRecord doesn't support aren't verified.
+func (c *Client) verifyApplied(ctx context.Context, r Record) error {
+	if c.VerifyTimeout <= 0 || !lookupTypes[strings.ToUpper(r.Type)] {
+		return nil
+	}
+	lookup := c.lookup
+	if lookup == nil {
+		lookup = lookupRecord
+	}
+	name, err := ToASCII(fqdn(r.Name))
+	if err != nil {
+		return fmt.Errorf("verify %s %s: %w", r.Name, r.Type, err)
+	}
+
+	nameservers := c.VerifyNameservers
+	if len(nameservers) == 0 {
+		nameservers, err = authoritativeNameservers(ctx, lookup, name)
+		if err != nil {
+			return fmt.Errorf("verify %s %s: %w", r.Name, r.Type, err)
+		}
+	}
+	err = WaitForRecordContext(ctx, name, r.Type, r.Data,
+		WaitResolvers(nameservers...),
+		WaitInterval(DefaultVerifyInterval),
+		WaitTimeout(c.VerifyTimeout),
+		func(cfg *waitConfig) { cfg.lookup = lookup },
+	)
+	if err != nil {
+		return fmt.Errorf("verify %s %s: %w: %w", r.Name, r.Type, ErrNotPropagated, err)
+	}
+	return nil
+}
+
+// authoritativeNameservers finds the name servers of the zone containing
+// name by asking DefaultResolvers for the NS records of name and then of
+// each parent in turn.
+func authoritativeNameservers(ctx context.Context, lookup func(ctx context.Context, resolver, name, recordType string) ([]string, error), name string) ([]string, error) {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	var lastErr error
+	for i := 0; i < len(labels)-1; i++ {
+		zone := strings.Join(labels[i:], ".") + "."
+		nameservers, err := lookup(ctx, DefaultResolvers[0], zone, "NS")
+		if err == nil && len(nameservers) > 0 {
+			return nameservers, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("no authoritative name servers found for %s: %w", name, lastErr)
+	}
+	return nil, fmt.Errorf("no authoritative name servers found for %s", name)
+}
@@ -0,0 +1,68 @@
+package regfishapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDNS answers lookups from a map keyed by resolver, name and type.
+type fakeDNS struct {
+	mu      sync.Mutex
+	answers map[string][]string
+	queries []string
+}
+
+func (d *fakeDNS) lookup(ctx context.Context, resolver, name, recordType string) ([]string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := resolver + " " + name + " " + recordType
+	d.queries = append(d.queries, key)
+	if answers, ok := d.answers[key]; ok {
+		return answers, nil
+	}
+	return nil, errors.New("no such record")
+}
+
+func TestVerifyAfterApply(t *testing.T) {
+	zone := newFakeZone(Record{ID: 1, Name: "www.example.com.", Type: "A", Data: "192.0.2.1"})
+	client := newTestClient(t, zone.ServeHTTP, WithVerifyAfterApply(50*time.Millisecond))
+	dns := &fakeDNS{answers: map[string][]string{
+		"1.1.1.1:53 example.com. NS":            {"ns1.regfish.de."},
+		"ns1.regfish.de.:53 www.example.com. A": {"192.0.2.2"},
+	}}
+	client.lookup = dns.lookup
+
+	rec, err := client.UpdateRecordById(1, NewARecord("www.example.com.", "192.0.2.2", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "192.0.2.2", rec.Data)
+	assert.Contains(t, dns.queries, "1.1.1.1:53 www.example.com. NS")
+
+	_, err = client.UpdateRecordById(1, NewARecord("www.example.com.", "192.0.2.3", 0))
+	assert.ErrorIs(t, err, ErrNotPropagated)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "192.0.2.3", zone.records[1].Data, "the update itself succeeded")
+}
+
+func TestVerifyAfterApplyNameservers(t *testing.T) {
+	zone := newFakeZone(Record{ID: 1, Name: "www.example.com.", Type: "TXT", Data: `"old"`})
+	client := newTestClient(t, zone.ServeHTTP, WithVerifyAfterApply(time.Second, "192.0.2.53"))
+	dns := &fakeDNS{answers: map[string][]string{
+		"192.0.2.53:53 www.example.com. TXT": {"new"},
+	}}
+	client.lookup = dns.lookup
+
+	_, err := client.UpdateRecord(Record{ID: 1, Name: "www.example.com.", Type: "TXT", Data: `"new"`})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.53:53 www.example.com. TXT"}, dns.queries)
+
+	// Types WaitForRecord can't look up aren't verified.
+	dns.queries = nil
+	_, err = client.UpdateRecordById(1, NewCAARecord("example.com.", 0, "issue", "letsencrypt.org", 0))
+	assert.NoError(t, err)
+	assert.Empty(t, dns.queries)
+}
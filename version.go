@@ -0,0 +1,8 @@
+package regfishapi
+
+// Version is the version of this library.
+const Version = "0.1.0"
+
+// DefaultUserAgent is the User-Agent header sent unless overridden with
+// WithUserAgent or Client.UserAgent.
+const DefaultUserAgent = "regfish-dnsapi-go/" + Version
@@ -0,0 +1,199 @@
+package regfishapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// DefaultResolvers are the public DNS resolvers WaitForRecord queries when
+// no others are given.
+var DefaultResolvers = []string{"1.1.1.1:53", "8.8.8.8:53"}
+
+// Defaults for WaitForRecord.
+const (
+	DefaultWaitInterval = 5 * time.Second
+	DefaultWaitTimeout  = 2 * time.Minute
+)
+
+type waitConfig struct {
+	resolvers []string
+	interval  time.Duration
+	timeout   time.Duration
+	lookup    func(ctx context.Context, resolver, name, recordType string) ([]string, error)
+}
+
+// WaitOption configures WaitForRecord.
+type WaitOption func(*waitConfig)
+
+// WaitResolvers makes WaitForRecord query the given resolvers, as
+// "host:port" or just the host for port 53, instead of DefaultResolvers.
+// The record must be visible on all of them.
+func WaitResolvers(resolvers ...string) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.resolvers = nil
+		for _, r := range resolvers {
+			if _, _, err := net.SplitHostPort(r); err != nil {
+				r = net.JoinHostPort(r, "53")
+			}
+			cfg.resolvers = append(cfg.resolvers, r)
+		}
+	}
+}
+
+// WaitInterval sets how long WaitForRecord waits between polls.
+func WaitInterval(d time.Duration) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.interval = d
+	}
+}
+
+// WaitTimeout sets how long WaitForRecord polls before giving up. Zero
+// means it waits until its context is done.
+func WaitTimeout(d time.Duration) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.timeout = d
+	}
+}
+
+// WaitForRecord polls public DNS resolvers until the record name of
+// recordType resolves to expectedData, e.g. to wait for an ACME DNS-01
+// challenge to become visible. A, AAAA, CNAME, MX, NS and TXT records are
+// supported; for MX records expectedData is the mail server. Names are
+// compared case-insensitively and with or without the trailing dot.
+//
+// If the record doesn't show up within the timeout, the error matches
+// context.DeadlineExceeded and describes what the resolvers returned last.
+func WaitForRecord(name, recordType, expectedData string, opts ...WaitOption) error {
+	return WaitForRecordContext(context.Background(), name, recordType, expectedData, opts...)
+}
+
+// WaitForRecordContext is like WaitForRecord but stops waiting when ctx is done.
+func WaitForRecordContext(ctx context.Context, name, recordType, expectedData string, opts ...WaitOption) error {
+	cfg := waitConfig{
+		resolvers: DefaultResolvers,
+		interval:  DefaultWaitInterval,
+		timeout:   DefaultWaitTimeout,
+		lookup:    lookupRecord,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	recordType = strings.ToUpper(recordType)
+	if !lookupTypes[recordType] {
+		return fmt.Errorf("waiting for %s records is not supported", recordType)
+	}
+	want := normalizeData(recordType, expectedData)
+
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+
+	var pending error
+	for {
+		pending = nil
+		for _, resolver := range cfg.resolvers {
+			answers, err := cfg.lookup(ctx, resolver, name, recordType)
+			if err == nil && !containsData(recordType, answers, want) {
+				err = fmt.Errorf("got %q", answers)
+			}
+			if err != nil {
+				pending = fmt.Errorf("%s: %w", resolver, err)
+				break
+			}
+		}
+		if pending == nil {
+			return nil
+		}
+		if err := sleepContext(ctx, cfg.interval); err != nil {
+			return fmt.Errorf("wait for %s %s: %w (last result: %v)", name, recordType, err, pending)
+		}
+	}
+}
+
+// containsData reports whether one of answers matches want, which has
+// been normalized already.
+func containsData(recordType string, answers []string, want string) bool {
+	for _, a := range answers {
+		if normalizeData(recordType, a) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupTypes are the record types lookupRecord supports.
+var lookupTypes = map[string]bool{"A": true, "AAAA": true, "CNAME": true, "MX": true, "NS": true, "TXT": true}
+
+// lookupRecord queries resolver for the records of name and recordType.
+func lookupRecord(ctx context.Context, resolver, name, recordType string) ([]string, error) {
+	r := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, resolver)
+		},
+	}
+
+	var answers []string
+	switch recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if recordType == "AAAA" {
+			network = "ip6"
+		}
+		addrs, err := r.LookupNetIP(ctx, network, name)
+		if err != nil {
+			return nil, lookupError(err)
+		}
+		for _, a := range addrs {
+			answers = append(answers, a.Unmap().String())
+		}
+	case "CNAME":
+		cname, err := r.LookupCNAME(ctx, name)
+		if err != nil {
+			return nil, lookupError(err)
+		}
+		answers = append(answers, cname)
+	case "MX":
+		mxs, err := r.LookupMX(ctx, name)
+		if err != nil {
+			return nil, lookupError(err)
+		}
+		for _, mx := range mxs {
+			answers = append(answers, mx.Host)
+		}
+	case "NS":
+		nss, err := r.LookupNS(ctx, name)
+		if err != nil {
+			return nil, lookupError(err)
+		}
+		for _, ns := range nss {
+			answers = append(answers, ns.Host)
+		}
+	case "TXT":
+		txts, err := r.LookupTXT(ctx, name)
+		if err != nil {
+			return nil, lookupError(err)
+		}
+		answers = txts
+	default:
+		return nil, fmt.Errorf("waiting for %s records is not supported", recordType)
+	}
+	return answers, nil
+}
+
+// lookupError turns "no such host" into a shorter message, since that is
+// the expected state while a new record propagates.
+func lookupError(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return errors.New("no such record")
+	}
+	return err
+}
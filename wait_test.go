@@ -0,0 +1,87 @@
+package regfishapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLookup returns a WaitOption answering lookups from answers, keyed
+// by resolver, and counts the calls.
+func fakeLookup(mu *sync.Mutex, answers map[string][]string, calls *int) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.lookup = func(ctx context.Context, resolver, name, recordType string) ([]string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			*calls++
+			if a, ok := answers[resolver]; ok {
+				return a, nil
+			}
+			return nil, errors.New("no such record")
+		}
+	}
+}
+
+func TestWaitForRecord(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	answers := map[string][]string{"192.0.2.53:53": {"other", "token"}}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		answers["198.51.100.53:53"] = []string{"token"}
+		mu.Unlock()
+	}()
+
+	err := WaitForRecord("_acme-challenge.example.com.", "txt", `"token"`,
+		WaitResolvers("192.0.2.53", "198.51.100.53:53"),
+		WaitInterval(5*time.Millisecond),
+		WaitTimeout(time.Second),
+		fakeLookup(&mu, answers, &calls))
+	assert.NoError(t, err)
+	assert.Greater(t, calls, 2)
+}
+
+func TestWaitForRecordNormalizes(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	answers := map[string][]string{"192.0.2.53:53": {"Target.Example.com."}}
+
+	err := WaitForRecord("www.example.com", "CNAME", "target.example.com",
+		WaitResolvers("192.0.2.53"), fakeLookup(&mu, answers, &calls))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWaitForRecordTimeout(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	answers := map[string][]string{"192.0.2.53:53": {"192.0.2.1"}}
+
+	err := WaitForRecord("www.example.com.", "A", "192.0.2.2",
+		WaitResolvers("192.0.2.53"),
+		WaitInterval(5*time.Millisecond),
+		WaitTimeout(30*time.Millisecond),
+		fakeLookup(&mu, answers, &calls))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, `192.0.2.53:53: got ["192.0.2.1"]`)
+}
+
+func TestWaitForRecordUnsupportedType(t *testing.T) {
+	_, err := lookupRecord(context.Background(), "192.0.2.53:53", "example.com.", "SRV")
+	assert.ErrorContains(t, err, "not supported")
+
+	var mu sync.Mutex
+	calls := 0
+	start := time.Now()
+	err = WaitForRecord("_sip._tcp.example.com.", "srv", "5 5060 sip.example.com.",
+		WaitTimeout(0), fakeLookup(&mu, map[string][]string{}, &calls))
+	assert.ErrorContains(t, err, "waiting for SRV records is not supported")
+	assert.Zero(t, calls)
+	assert.Less(t, time.Since(start), time.Second)
+}
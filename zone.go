@@ -0,0 +1,54 @@
+package regfishapi
+
+import (
+	"context"
+	"strings"
+)
+
+// AbsoluteName returns the fully qualified form of name relative to
+// domain: "www" becomes "www.example.com." and "" or "@" the apex
+// "example.com.". Names that already end in a dot are returned unchanged.
+//
+// As in a zonefile, a name without trailing dot is always relative, so
+// "example.com" becomes "example.com.example.com.". The methods taking a
+// domain are more forgiving and also accept the bare domain for the apex.
+func AbsoluteName(name, domain string) string {
+	domain = fqdn(domain)
+	switch {
+	case name == "" || name == "@":
+		return domain
+	case strings.HasSuffix(name, "."):
+		return name
+	case domain == ".":
+		return name + "."
+	}
+	return name + "." + domain
+}
+
+// CreateRecordInZone is like CreateRecord but takes the name of record
+// relative to domain, as by AbsoluteName, so that
+//
+//	client.CreateRecordInZone("example.com", regfishapi.NewARecord("www", "192.0.2.1", 0))
+//
+// creates www.example.com. An empty name, "@" and domain itself refer to
+// the apex.
+func (c *Client) CreateRecordInZone(domain string, record Record) (Record, error) {
+	return c.CreateRecordInZoneContext(context.Background(), domain, record)
+}
+
+// CreateRecordInZoneContext is like CreateRecordInZone but uses ctx for the underlying request.
+func (c *Client) CreateRecordInZoneContext(ctx context.Context, domain string, record Record) (Record, error) {
+	record.Name = AbsoluteName(apexName(record.Name, domain), domain)
+	return c.CreateRecordContext(ctx, record)
+}
+
+// apexName returns the canonical name of the apex of domain, the fully
+// qualified domain, if name is one of the forms the apex can be given in:
+// "", "@" or domain with or without trailing dot. Other names are returned
+// unchanged.
+func apexName(name, domain string) string {
+	if name == "" || name == "@" || sameName(name, domain) {
+		return fqdn(domain)
+	}
+	return name
+}
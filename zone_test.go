@@ -0,0 +1,72 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAbsoluteName(t *testing.T) {
+	tests := []struct {
+		name, domain, want string
+	}{
+		{"www", "example.com", "www.example.com."},
+		{"www", "example.com.", "www.example.com."},
+		{"_acme-challenge.www", "example.com", "_acme-challenge.www.example.com."},
+		{"", "example.com", "example.com."},
+		{"@", "example.com", "example.com."},
+		{"www.example.org.", "example.com", "www.example.org."},
+		{"www", ".", "www."},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, AbsoluteName(tt.name, tt.domain), "%q in %q", tt.name, tt.domain)
+	}
+}
+
+func TestCreateRecordInZone(t *testing.T) {
+	var sent Record
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"response":{"id":1}}`))
+	})
+
+	_, err := client.CreateRecordInZone("example.com", NewARecord("www", "192.0.2.1", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "www.example.com.", sent.Name)
+
+	_, err = client.CreateRecordInZone("example.com", NewMXRecord("@", 10, "mail.example.com.", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com.", sent.Name)
+
+	_, err = client.CreateRecordInZone("example.com", NewARecord("Example.com", "192.0.2.1", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com.", sent.Name)
+}
+
+func TestApexForms(t *testing.T) {
+	for _, name := range []string{"", "@", "example.com", "example.com."} {
+		assert.Equal(t, "example.com.", apexName(name, "example.com"), "%q", name)
+	}
+	assert.Equal(t, "www", apexName("www", "example.com"))
+
+	client := newTestClient(t, zoneHandler(testZone))
+	for _, name := range []string{"@", "example.com"} {
+		recs, err := client.FindRecords("example.com", name, "")
+		assert.NoError(t, err)
+		if assert.Len(t, recs, 1, "%q", name) {
+			assert.Equal(t, "NS", recs[0].Type)
+		}
+	}
+
+	zone := newFakeZone()
+	client = newTestClient(t, zone.ServeHTTP)
+	for _, name := range []string{"", "@", "example.com", "example.com."} {
+		_, err := client.UpsertRecord("example.com", NewARecord(name, "192.0.2.1", 0))
+		assert.NoError(t, err, "%q", name)
+	}
+	if assert.Len(t, zone.records, 1) {
+		assert.Equal(t, "example.com.", zone.records[100].Name)
+	}
+}
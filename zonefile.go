@@ -0,0 +1,106 @@
+package regfishapi
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultZoneTTL is the $TTL written by ExportZone. Records without a TTL of
+// their own inherit it.
+const DefaultZoneTTL = 3600
+
+// ExportZone renders all records of domain as an RFC 1035 zonefile.
+func (c *Client) ExportZone(domain string) (string, error) {
+	return c.ExportZoneContext(context.Background(), domain)
+}
+
+// ExportZoneContext is like ExportZone but uses ctx for the underlying requests.
+func (c *Client) ExportZoneContext(ctx context.Context, domain string) (string, error) {
+	records, err := c.GetRecordsByDomainContext(ctx, domain)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := WriteZonefile(&b, domain, records); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// WriteZonefile writes records as a zonefile for origin to w. Names inside
+// origin are written relative to it, with "@" for the apex.
+func WriteZonefile(w io.Writer, origin string, records []Record) error {
+	origin = fqdn(origin)
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(bw, "$TTL %d\n", DefaultZoneTTL)
+	for _, r := range records {
+		fmt.Fprintln(bw, zonefileLine(origin, r))
+	}
+	return bw.Flush()
+}
+
+// zonefileLine formats r as a single zonefile resource record line.
+func zonefileLine(origin string, r Record) string {
+	fields := []string{relativeName(r.Name, origin)}
+	if r.TTL != nil {
+		fields = append(fields, fmt.Sprint(*r.TTL))
+	}
+	fields = append(fields, "IN", strings.ToUpper(r.Type), zonefileData(r))
+	return strings.Join(fields, "\t")
+}
+
+// String renders r as a zonefile line such as
+// "www.example.com. 300 IN A 192.0.2.1", leaving out an unset TTL.
+func (r Record) String() string {
+	fields := []string{r.Name}
+	if r.TTL != nil {
+		fields = append(fields, fmt.Sprint(*r.TTL))
+	}
+	fields = append(fields, "IN", strings.ToUpper(r.Type), zonefileData(r))
+	return strings.Join(fields, " ")
+}
+
+// zonefileData returns the RDATA of r in presentation format.
+func zonefileData(r Record) string {
+	switch strings.ToUpper(r.Type) {
+	case "MX", "SRV":
+		if r.Priority != nil {
+			return fmt.Sprintf("%d %s", *r.Priority, r.Data)
+		}
+	case "TXT":
+		if !strings.HasPrefix(strings.TrimSpace(r.Data), `"`) {
+			return formatTXT(r.Data)
+		}
+	case "CAA":
+		if r.Flags != nil && r.Tag != nil {
+			return fmt.Sprintf("%d %s %s", *r.Flags, *r.Tag, quoteTXT(strings.Trim(r.Data, `"`)))
+		}
+	}
+	return r.Data
+}
+
+// relativeName returns name relative to origin, "@" for origin itself, or
+// name as an absolute name if it lies outside origin.
+func relativeName(name, origin string) string {
+	name = fqdn(name)
+	if strings.EqualFold(name, origin) {
+		return "@"
+	}
+	if suffix := "." + origin; len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		return name[:len(name)-len(suffix)]
+	}
+	return name
+}
+
+// fqdn returns name with a trailing dot.
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
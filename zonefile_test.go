@@ -0,0 +1,49 @@
+package regfishapi
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportZone(t *testing.T) {
+	client := newTestClient(t, zoneHandler(`{"response":[
+		{"id":1,"name":"example.com.","type":"NS","data":"ns1.regfish.de."},
+		{"id":2,"name":"www.example.com.","type":"A","data":"192.0.2.1","ttl":300},
+		{"id":3,"name":"example.com.","type":"MX","data":"mail.example.com.","priority":0},
+		{"id":4,"name":"example.com.","type":"TXT","data":"v=spf1 -all"},
+		{"id":5,"name":"_sip._tcp.example.com.","type":"SRV","data":"60 5060 sip.example.com.","priority":10},
+		{"id":6,"name":"example.com.","type":"CAA","data":"letsencrypt.org","flags":0,"tag":"issue"},
+		{"id":7,"name":"other.example.net.","type":"CNAME","data":"example.com."}
+	]}`))
+
+	zone, err := client.ExportZone("example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "$ORIGIN example.com.\n"+
+		"$TTL 3600\n"+
+		"@\tIN\tNS\tns1.regfish.de.\n"+
+		"www\t300\tIN\tA\t192.0.2.1\n"+
+		"@\tIN\tMX\t0 mail.example.com.\n"+
+		"@\tIN\tTXT\t\"v=spf1 -all\"\n"+
+		"_sip._tcp\tIN\tSRV\t10 60 5060 sip.example.com.\n"+
+		"@\tIN\tCAA\t0 issue \"letsencrypt.org\"\n"+
+		"other.example.net.\tIN\tCNAME\texample.com.\n", zone)
+}
+
+func TestExportZoneError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.ExportZone("example.com")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRecordString(t *testing.T) {
+	assert.Equal(t, "www.example.com. 300 IN A 192.0.2.1", NewARecord("www.example.com.", "192.0.2.1", 300).String())
+	assert.Equal(t, "example.com. IN MX 10 mail.example.com.", NewMXRecord("example.com.", 10, "mail.example.com.", 0).String())
+	assert.Equal(t, `example.com. IN CAA 0 issue "letsencrypt.org"`, NewCAARecord("example.com.", 0, CAATagIssue, "letsencrypt.org", 0).String())
+	assert.Equal(t, "www.example.com. 60 IN A 192.0.2.1", fmt.Sprint(Record{Name: "www.example.com.", Type: "a", Data: "192.0.2.1", TTL: IntPtr(60)}))
+}
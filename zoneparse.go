@@ -0,0 +1,324 @@
+package regfishapi
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ZonefileError reports a problem on a specific line of a zonefile.
+type ZonefileError struct {
+	Line int
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *ZonefileError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ZonefileError) Unwrap() error {
+	return e.Err
+}
+
+// ImportZone parses zonefile and creates its records in domain. The zonefile
+// is parsed completely before anything is created; if any line fails to
+// parse, the per-line errors are returned and nothing is created. SOA
+// records are skipped, since the zone's SOA is managed by Regfish. Creation
+// failures are reported per record as by CreateRecords.
+func (c *Client) ImportZone(domain string, zonefile io.Reader) ([]Record, error) {
+	return c.ImportZoneContext(context.Background(), domain, zonefile)
+}
+
+// ImportZoneContext is like ImportZone but uses ctx for the underlying requests.
+func (c *Client) ImportZoneContext(ctx context.Context, domain string, zonefile io.Reader) ([]Record, error) {
+	records, err := ParseZonefile(zonefile, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var toCreate []Record
+	for _, r := range records {
+		if !strings.EqualFold(r.Type, "SOA") {
+			toCreate = append(toCreate, r)
+		}
+	}
+	return c.CreateRecordsContext(ctx, toCreate)
+}
+
+// ParseZonefile parses an RFC 1035 zonefile into records with absolute
+// names. origin is the initial $ORIGIN and may be overridden by the file.
+// $ORIGIN, $TTL, "@", blank owners, relative names and multi-line records
+// in parentheses are supported; $INCLUDE is not. All lines are parsed and
+// every failure is reported as a *ZonefileError, joined into one error.
+func ParseZonefile(r io.Reader, origin string) ([]Record, error) {
+	p := &zoneParser{origin: fqdn(origin)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var errs []error
+	var pending []string
+	var pendingLine int
+	var pendingBlank bool
+	depth := 0
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		tokens, opens, err := tokenizeZoneLine(line)
+		if err != nil {
+			errs = append(errs, &ZonefileError{Line: lineNo, Err: err})
+			continue
+		}
+		if depth == 0 {
+			pending = nil
+			pendingLine = lineNo
+			pendingBlank = line != "" && (line[0] == ' ' || line[0] == '\t')
+		}
+		pending = append(pending, tokens...)
+		depth += opens
+		if depth < 0 {
+			errs = append(errs, &ZonefileError{Line: lineNo, Err: errors.New("unbalanced parentheses")})
+			depth = 0
+			continue
+		}
+		if depth > 0 || len(pending) == 0 {
+			continue
+		}
+		if err := p.entry(pending, pendingBlank); err != nil {
+			errs = append(errs, &ZonefileError{Line: pendingLine, Err: err})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	if depth > 0 {
+		errs = append(errs, &ZonefileError{Line: pendingLine, Err: errors.New("unterminated parentheses")})
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return p.records, nil
+}
+
+// zoneParser holds the state carried between zonefile entries.
+type zoneParser struct {
+	origin    string
+	ttl       *int
+	lastOwner string
+	records   []Record
+}
+
+// entry processes one logical zonefile entry. blankOwner is set when the
+// entry started with whitespace and so inherits the previous owner.
+func (p *zoneParser) entry(tokens []string, blankOwner bool) error {
+	switch strings.ToUpper(tokens[0]) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return errors.New("$ORIGIN takes exactly one argument")
+		}
+		p.origin = p.absolute(tokens[1])
+		return nil
+	case "$TTL":
+		if len(tokens) != 2 {
+			return errors.New("$TTL takes exactly one argument")
+		}
+		ttl, err := parseZoneTTL(tokens[1])
+		if err != nil {
+			return err
+		}
+		p.ttl = &ttl
+		return nil
+	case "$INCLUDE":
+		return errors.New("$INCLUDE is not supported")
+	}
+
+	owner := p.lastOwner
+	if !blankOwner {
+		owner = p.absolute(tokens[0])
+		tokens = tokens[1:]
+	}
+	if owner == "" {
+		return errors.New("record without owner name")
+	}
+	p.lastOwner = owner
+
+	ttl := p.ttl
+	for len(tokens) > 0 {
+		if isZoneClass(tokens[0]) {
+			tokens = tokens[1:]
+			continue
+		}
+		if v, err := parseZoneTTL(tokens[0]); err == nil {
+			ttl = &v
+			tokens = tokens[1:]
+			continue
+		}
+		break
+	}
+	if len(tokens) < 2 {
+		return errors.New("missing record type or data")
+	}
+
+	rec := Record{Name: owner, Type: strings.ToUpper(tokens[0])}
+	if ttl != nil {
+		rec.TTL = IntPtr(*ttl)
+	}
+	if err := p.rdata(&rec, tokens[1:]); err != nil {
+		return fmt.Errorf("%s %s: %w", rec.Name, rec.Type, err)
+	}
+	p.records = append(p.records, rec)
+	return nil
+}
+
+// rdata fills the data fields of rec from the RDATA tokens.
+func (p *zoneParser) rdata(rec *Record, rdata []string) error {
+	switch rec.Type {
+	case "CNAME", "NS", "PTR":
+		if len(rdata) != 1 {
+			return fmt.Errorf("expected 1 field, got %d", len(rdata))
+		}
+		rec.Data = p.absolute(rdata[0])
+	case "MX":
+		if len(rdata) != 2 {
+			return fmt.Errorf("expected 2 fields, got %d", len(rdata))
+		}
+		prio, err := strconv.Atoi(rdata[0])
+		if err != nil {
+			return fmt.Errorf("invalid preference %q", rdata[0])
+		}
+		rec.Priority = &prio
+		rec.Data = p.absolute(rdata[1])
+	case "SRV":
+		if len(rdata) != 4 {
+			return fmt.Errorf("expected 4 fields, got %d", len(rdata))
+		}
+		prio, err := strconv.Atoi(rdata[0])
+		if err != nil {
+			return fmt.Errorf("invalid priority %q", rdata[0])
+		}
+		rec.Priority = &prio
+		rec.Data = fmt.Sprintf("%s %s %s", rdata[1], rdata[2], p.absolute(rdata[3]))
+	case "CAA":
+		if len(rdata) != 3 {
+			return fmt.Errorf("expected 3 fields, got %d", len(rdata))
+		}
+		flags, err := strconv.Atoi(rdata[0])
+		if err != nil {
+			return fmt.Errorf("invalid flags %q", rdata[0])
+		}
+		tag := rdata[1]
+		rec.Flags = &flags
+		rec.Tag = &tag
+		rec.Data = parseTXT(rdata[2])
+	default:
+		rec.Data = strings.Join(rdata, " ")
+	}
+	return nil
+}
+
+// absolute resolves a zonefile name against the current origin.
+func (p *zoneParser) absolute(name string) string {
+	return AbsoluteName(name, p.origin)
+}
+
+// isZoneClass reports whether s is a DNS class mnemonic.
+func isZoneClass(s string) bool {
+	switch strings.ToUpper(s) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	}
+	return false
+}
+
+// parseZoneTTL parses a TTL given in seconds or with BIND unit suffixes,
+// e.g. "3600", "1h" or "1h30m".
+func parseZoneTTL(s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n, nil
+	}
+	total, num, units := 0, -1, 0
+	for _, ch := range strings.ToLower(s) {
+		if ch >= '0' && ch <= '9' {
+			if num < 0 {
+				num = 0
+			}
+			num = num*10 + int(ch-'0')
+			continue
+		}
+		unit := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}[ch]
+		if unit == 0 || num < 0 {
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+		total += num * unit
+		num = -1
+		units++
+	}
+	if num >= 0 || units == 0 {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+	return total, nil
+}
+
+// tokenizeZoneLine splits a zonefile line into whitespace-separated tokens,
+// keeping quoted strings (with their quotes) as single tokens and dropping
+// comments. It returns the net number of opened parentheses, which are not
+// returned as tokens.
+func tokenizeZoneLine(line string) ([]string, int, error) {
+	var tokens []string
+	var cur strings.Builder
+	inToken, inQuotes := false, false
+	depth := 0
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+			inToken = false
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		if inQuotes {
+			cur.WriteByte(ch)
+			switch ch {
+			case '\\':
+				if i+1 < len(line) {
+					i++
+					cur.WriteByte(line[i])
+				}
+			case '"':
+				inQuotes = false
+			}
+			continue
+		}
+		switch ch {
+		case ';':
+			flush()
+			return tokens, depth, nil
+		case ' ', '\t', '\r':
+			flush()
+		case '(':
+			flush()
+			depth++
+		case ')':
+			flush()
+			depth--
+		case '"':
+			inToken, inQuotes = true, true
+			cur.WriteByte(ch)
+		default:
+			inToken = true
+			cur.WriteByte(ch)
+		}
+	}
+	if inQuotes {
+		return nil, 0, errors.New("unterminated quoted string")
+	}
+	flush()
+	return tokens, depth, nil
+}
@@ -0,0 +1,121 @@
+package regfishapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testZonefile = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.regfish.de. hostmaster.example.com. (
+		2024010101 ; serial
+		3600 900 604800 300 )
+@		IN	NS	ns1.regfish.de.
+		IN	MX	10 mail
+www	300	IN	A	192.0.2.1
+	IN 300	AAAA	2001:db8::1
+txt		TXT	"v=spf1 -all" "second; part"
+_sip._tcp	SRV	10 60 5060 sip.example.com.
+@	CAA	0 issue "letsencrypt.org"
+$ORIGIN sub.example.com.
+host	A	192.0.2.2 ; comment
+`
+
+func TestParseZonefile(t *testing.T) {
+	records, err := ParseZonefile(strings.NewReader(testZonefile), "example.com")
+	assert.NoError(t, err)
+	if !assert.Len(t, records, 9) {
+		return
+	}
+
+	soa := records[0]
+	assert.Equal(t, "SOA", soa.Type)
+	assert.Equal(t, "ns1.regfish.de. hostmaster.example.com. 2024010101 3600 900 604800 300", soa.Data)
+	assert.Equal(t, IntPtr(3600), soa.TTL)
+
+	mx := records[2]
+	assert.Equal(t, "example.com.", mx.Name)
+	assert.Equal(t, "mail.example.com.", mx.Data)
+	assert.Equal(t, 10, *mx.Priority)
+
+	assert.Equal(t, Record{Name: "www.example.com.", Type: "A", Data: "192.0.2.1", TTL: IntPtr(300)}, records[3])
+	assert.Equal(t, Record{Name: "www.example.com.", Type: "AAAA", Data: "2001:db8::1", TTL: IntPtr(300)}, records[4])
+
+	txt := records[5]
+	assert.Equal(t, `"v=spf1 -all" "second; part"`, txt.Data)
+	assert.Equal(t, "v=spf1 -allsecond; part", txt.TXTValue())
+
+	srv := records[6]
+	assert.Equal(t, "_sip._tcp.example.com.", srv.Name)
+	assert.Equal(t, "60 5060 sip.example.com.", srv.Data)
+
+	caa := records[7]
+	assert.Equal(t, "letsencrypt.org", caa.Data)
+	assert.Equal(t, "issue", *caa.Tag)
+
+	assert.Equal(t, "host.sub.example.com.", records[8].Name)
+}
+
+func TestParseZonefileErrors(t *testing.T) {
+	_, err := ParseZonefile(strings.NewReader("www A 192.0.2.1\nbad MX mail\nok A 192.0.2.2\nquote TXT \"open\n"), "example.com.")
+
+	var lines []int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var zerr *ZonefileError
+		if errors.As(e, &zerr) {
+			lines = append(lines, zerr.Line)
+		}
+	}
+	assert.Equal(t, []int{2, 4}, lines)
+}
+
+func TestParseZonefileTTL(t *testing.T) {
+	for in, want := range map[string]int{"300": 300, "1h": 3600, "1h30m": 5400, "1W": 604800, "0": 0, "0s": 0} {
+		got, err := parseZoneTTL(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, in := range []string{"", "h", "5x", "IN"} {
+		_, err := parseZoneTTL(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestImportZone(t *testing.T) {
+	var mu sync.Mutex
+	var created []Record
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var rec Record
+		json.NewDecoder(r.Body).Decode(&rec)
+		mu.Lock()
+		created = append(created, rec)
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]Record{"response": rec})
+	})
+
+	records, err := client.ImportZone("example.com", strings.NewReader(testZonefile))
+	assert.NoError(t, err)
+	assert.Len(t, records, 8)
+	assert.Len(t, created, 8)
+	for _, r := range created {
+		assert.NotEqual(t, "SOA", r.Type)
+	}
+}
+
+func TestImportZoneParseErrorCreatesNothing(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("records were created despite parse errors")
+	})
+
+	_, err := client.ImportZone("example.com", strings.NewReader("www A 192.0.2.1\nbad MX\n"))
+	var zerr *ZonefileError
+	if assert.True(t, errors.As(err, &zerr)) {
+		assert.Equal(t, 2, zerr.Line)
+	}
+}